@@ -3,6 +3,7 @@
 mkdir -p templates/plugins
 
 cp ../../*/assets/* templates/plugins/
+cp ../../common/*/assets/* templates/plugins/
 
 # cp ../../automod/assets/* templates/plugins/
 # cp ../../automod_legacy/assets/* templates/plugins/
@@ -13,6 +13,7 @@ import (
 	"github.com/jonas747/yagpdb/bot/paginatedmessages"
 	"github.com/jonas747/yagpdb/common/internalapi"
 	"github.com/jonas747/yagpdb/common/scheduledevents2"
+	"github.com/jonas747/yagpdb/common/scheduledevents2/inspector"
 
 	// Plugin imports
 	"github.com/jonas747/yagpdb/automod"
@@ -32,6 +33,7 @@ import (
 	"github.com/jonas747/yagpdb/reminders"
 	"github.com/jonas747/yagpdb/reputation"
 	"github.com/jonas747/yagpdb/rolecommands"
+	"github.com/jonas747/yagpdb/rss"
 	"github.com/jonas747/yagpdb/rsvp"
 	"github.com/jonas747/yagpdb/safebrowsing"
 	"github.com/jonas747/yagpdb/serverstats"
@@ -82,7 +84,9 @@ func main() {
 	premium.RegisterPlugin()
 	patreonpremiumsource.RegisterPlugin()
 	scheduledevents2.RegisterPlugin()
+	inspector.RegisterPlugin()
 	twitter.RegisterPlugin()
+	rss.RegisterPlugin()
 	rsvp.RegisterPlugin()
 	timezonecompanion.RegisterPlugin()
 	admin.RegisterPlugin()
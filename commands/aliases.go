@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jonas747/dcmd/v3"
+	"github.com/jonas747/yagpdb/common"
+)
+
+// MaxAliases is the max number of command aliases per server
+const MaxAliases = 100
+
+// CommandAlias maps a server specific name to a built-in command, optionally with preset arguments and switches
+type CommandAlias struct {
+	ID      int64
+	GuildID int64
+
+	// Name is always lowercase
+	Name string
+
+	// Command is the full name of the command, e.g "Ban" or "Role Add"
+	Command string
+
+	// Args are added after the arguments the user provided
+	Args string
+}
+
+// GetAliases returns all the command aliases of the guild
+func GetAliases(ctx context.Context, guildID int64) ([]*CommandAlias, error) {
+	const q = `SELECT id, guild_id, name, command, args FROM commands_aliases WHERE guild_id=$1 ORDER BY name ASC;`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*CommandAlias, 0)
+	for rows.Next() {
+		var a CommandAlias
+		err = rows.Scan(&a.ID, &a.GuildID, &a.Name, &a.Command, &a.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &a)
+	}
+
+	return result, rows.Err()
+}
+
+// SaveAlias creates the alias, or updates the existing one with the same name
+func SaveAlias(ctx context.Context, alias *CommandAlias) error {
+	const q = `
+INSERT INTO commands_aliases (guild_id, name, command, args) VALUES ($1, $2, $3, $4)
+ON CONFLICT (guild_id, name) DO UPDATE SET command=$3, args=$4
+RETURNING id;
+`
+
+	alias.Name = strings.ToLower(alias.Name)
+	return common.PQ.QueryRowContext(ctx, q, alias.GuildID, alias.Name, alias.Command, alias.Args).Scan(&alias.ID)
+}
+
+// DeleteAlias deletes the alias, returns false if it did not exist
+func DeleteAlias(ctx context.Context, guildID int64, id int64) (bool, error) {
+	result, err := common.PQ.ExecContext(ctx, `DELETE FROM commands_aliases WHERE guild_id=$1 AND id=$2;`, guildID, id)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+var cachedAliases = common.CacheSet.RegisterSlot("commands_aliases", nil, int64(0))
+
+// BotCachedGetAliases returns the command aliases of the guild using the cache
+func BotCachedGetAliases(guildID int64) ([]*CommandAlias, error) {
+	v, err := cachedAliases.GetCustomFetch(guildID, func(key interface{}) (interface{}, error) {
+		return GetAliases(context.Background(), guildID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]*CommandAlias), nil
+}
+
+// applyAlias rewrites the content to invoke the command the alias points to, returns false if no alias was invoked
+func applyAlias(aliases []*CommandAlias, prefix, content string) (string, bool) {
+	if prefix == "" || !strings.HasPrefix(content, prefix) {
+		return content, false
+	}
+
+	rest := content[len(prefix):]
+	name := rest
+	args := ""
+	if i := strings.IndexFunc(rest, unicode.IsSpace); i != -1 {
+		name = rest[:i]
+		args = strings.TrimSpace(rest[i:])
+	}
+
+	if name == "" {
+		return content, false
+	}
+
+	for _, v := range aliases {
+		if !strings.EqualFold(v.Name, name) {
+			continue
+		}
+
+		out := prefix + v.Command
+		if args != "" {
+			out += " " + args
+		}
+
+		if v.Args != "" {
+			out += " " + v.Args
+		}
+
+		return out, true
+	}
+
+	return content, false
+}
+
+// FindCommandFullName returns the full name of the built-in command by one of its names or aliases,
+// e.g "b" returns "Ban", returns an empty string if there's no such command
+func FindCommandFullName(name string) string {
+	fields := strings.Fields(name)
+	if len(fields) < 1 || len(fields) > 2 {
+		return ""
+	}
+
+	for _, cmd := range CommandSystem.Root.Commands {
+		switch t := cmd.Command.(type) {
+		case *YAGCommand:
+			if len(fields) == 1 && containsFold(cmd.Trigger.Names, fields[0]) {
+				return cmd.Trigger.Names[0]
+			}
+		case *dcmd.Container:
+			if len(fields) != 2 || !containsFold(t.Names, fields[0]) {
+				continue
+			}
+
+			for _, containerCmd := range t.Commands {
+				if containsFold(containerCmd.Trigger.Names, fields[1]) {
+					return t.Names[0] + " " + containerCmd.Trigger.Names[0]
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func containsFold(names []string, name string) bool {
+	for _, v := range names {
+		if strings.EqualFold(v, name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"testing"
+)
+
+func TestApplyAlias(t *testing.T) {
+	aliases := []*CommandAlias{
+		{Name: "b", Command: "Ban", Args: "-ddays 1"},
+		{Name: "w", Command: "Warn"},
+		{Name: "ban", Command: "Ban", Args: "-ddays 0"},
+	}
+
+	cases := []struct {
+		prefix, content string
+		expected        string
+		ok              bool
+	}{
+		{"-", "-b @user spam", "-Ban @user spam -ddays 1", true},
+		{"-", "-B @user", "-Ban @user -ddays 1", true},
+		{"-", "-w\n@user being rude", "-Warn @user being rude", true},
+		{"-", "-w", "-Warn", true},
+		{"-", "-ban @user", "-Ban @user -ddays 0", true},
+		{"-", "-bb @user", "-bb @user", false},
+		{"-", "!b @user", "!b @user", false},
+		{"-", "-", "-", false},
+		{"yag ", "yag b @user", "yag Ban @user -ddays 1", true},
+	}
+
+	for _, c := range cases {
+		out, ok := applyAlias(aliases, c.prefix, c.content)
+		if out != c.expected || ok != c.ok {
+			t.Errorf("applyAlias(%q, %q) = %q, %t, expected %q, %t", c.prefix, c.content, out, ok, c.expected, c.ok)
+		}
+	}
+}
+
+func TestChannelPrefixes(t *testing.T) {
+	prefixes := &channelPrefixes{
+		Channels:   map[int64]string{1: "!"},
+		Categories: map[int64]string{10: "?", 11: "."},
+	}
+
+	cases := []struct {
+		channel, parent int64
+		expected        string
+	}{
+		{1, 10, "!"},
+		{2, 10, "?"},
+		{3, 11, "."},
+		{4, 0, ""},
+		{5, 12, ""},
+	}
+
+	for _, c := range cases {
+		if got := prefixes.get(c.channel, c.parent); got != c.expected {
+			t.Errorf("get(%d, %d) = %q, expected %q", c.channel, c.parent, got, c.expected)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"context"
+	"sync"
 
 	"github.com/jonas747/dcmd/v3"
 	"github.com/jonas747/discordgo"
@@ -36,7 +37,9 @@ var (
 // These functions are called on every message, and should return true if the message should be checked for commands, false otherwise
 var MessageFilterFuncs []MessageFilterFunc
 
-type Plugin struct{}
+type Plugin struct {
+	stopBGWorker chan *sync.WaitGroup
+}
 
 func (p *Plugin) PluginInfo() *common.PluginInfo {
 	return &common.PluginInfo{
@@ -47,7 +50,9 @@ func (p *Plugin) PluginInfo() *common.PluginInfo {
 }
 
 func RegisterPlugin() {
-	plugin := &Plugin{}
+	plugin := &Plugin{
+		stopBGWorker: make(chan *sync.WaitGroup),
+	}
 	common.RegisterPlugin(plugin)
 	err := common.GORM.AutoMigrate(&common.LoggedExecutedCommand{}).Error
 	if err != nil {
@@ -80,6 +85,7 @@ func InitCommands() {
 	CommandSystem.Root.AddMidlewares(YAGCommandMiddleware)
 	CommandSystem.Root.AddCommand(cmdHelp, cmdHelp.GetTrigger())
 	CommandSystem.Root.AddCommand(cmdPrefix, cmdPrefix.GetTrigger())
+	CommandSystem.Root.AddCommand(cmdCommandStats, cmdCommandStats.GetTrigger())
 
 	for _, v := range common.Plugins {
 		if adder, ok := v.(CommandProvider); ok {
@@ -102,6 +108,9 @@ var _ featureflags.PluginWithFeatureFlags = (*Plugin)(nil)
 const (
 	featureFlagHasCustomPrefix    = "commands_has_custom_prefix"
 	featureFlagHasCustomOverrides = "commands_has_custom_overrides"
+	featureFlagHasChannelPrefixes = "commands_has_channel_prefixes"
+	featureFlagHasAliases         = "commands_has_aliases"
+	featureFlagHasGuildSlashCmds  = "commands_has_guild_slash_commands"
 )
 
 func (p *Plugin) UpdateFeatureFlags(guildID int64) ([]string, error) {
@@ -125,6 +134,31 @@ func (p *Plugin) UpdateFeatureFlags(guildID int64) ([]string, error) {
 		flags = append(flags, featureFlagHasCustomOverrides)
 	}
 
+	for _, v := range channelOverrides {
+		if !v.Global && v.Prefix != "" {
+			flags = append(flags, featureFlagHasChannelPrefixes)
+			break
+		}
+	}
+
+	aliases, err := GetAliases(context.Background(), guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(aliases) > 0 {
+		flags = append(flags, featureFlagHasAliases)
+	}
+
+	slashSettings, err := GetGuildSlashSettings(context.Background(), guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if slashSettings.GuildCommands {
+		flags = append(flags, featureFlagHasGuildSlashCmds)
+	}
+
 	return flags, nil
 }
 
@@ -153,5 +187,8 @@ func (p *Plugin) AllFeatureFlags() []string {
 	return []string{
 		featureFlagHasCustomPrefix,    // Set if the server has a custom command prefix
 		featureFlagHasCustomOverrides, // set if the server has custom command and/or channel overrides
+		featureFlagHasChannelPrefixes, // set if the server has channel overrides with their own prefix
+		featureFlagHasAliases,         // set if the server has command aliases
+		featureFlagHasGuildSlashCmds,  // set if the server registers the slash commands on the server itself
 	}
 }
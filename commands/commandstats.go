@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jonas747/dcmd/v3"
+	"github.com/jonas747/discordgo"
+)
+
+var cmdCommandStats = &YAGCommand{
+	Name:                "CommandStats",
+	Aliases:             []string{"cmdstats"},
+	Description:         "Shows the most used commands, the top users and why members were denied running commands on this server",
+	LongDescription:     fmt.Sprintf("Usage is kept for %d days, see the control panel for the full list of commands.", int(UsageLogRetention.Hours()/24)),
+	CmdCategory:         CategoryTool,
+	RequireDiscordPerms: []int64{discordgo.PermissionManageServer},
+	Arguments: []*dcmd.ArgDef{
+		{Name: "Days", Type: &dcmd.IntArg{Min: 1, Max: int64(UsageLogRetention.Hours() / 24)}, Default: 7},
+	},
+
+	RunFunc:  cmdFuncCommandStats,
+	Cooldown: 10,
+}
+
+func cmdFuncCommandStats(data *dcmd.Data) (interface{}, error) {
+	days := data.Args[0].Int()
+	since := time.Now().Add(-time.Hour * 24 * time.Duration(days))
+
+	stats, err := GetUsageStats(data.Context(), data.GuildData.GS.ID, since, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	var commands strings.Builder
+	for i, v := range stats.Commands {
+		if i >= 10 {
+			fmt.Fprintf(&commands, "(+%d more)", len(stats.Commands)-i)
+			break
+		}
+
+		fmt.Fprintf(&commands, "`%s`: %d", v.Command, v.Uses)
+		if v.Errors > 0 || v.Denied > 0 {
+			fmt.Fprintf(&commands, " (%d errors, %d denied)", v.Errors, v.Denied)
+		}
+		commands.WriteString("\n")
+	}
+
+	var users strings.Builder
+	for _, v := range stats.TopUsers {
+		fmt.Fprintf(&users, "<@%d>: %d\n", v.UserID, v.Uses)
+	}
+
+	var denials strings.Builder
+	for _, v := range stats.Denials {
+		fmt.Fprintf(&denials, "%s: %d\n", v.Reason, v.Count)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Command usage in the last %d day(s)", days),
+		Description: fmt.Sprintf("%d commands used", stats.Total),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Most used commands", Value: orNone(commands.String())},
+			{Name: "Top users", Value: orNone(users.String()), Inline: true},
+			{Name: "Denied because of", Value: orNone(denials.String()), Inline: true},
+		},
+	}
+
+	return embed, nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "None"
+	}
+
+	return s
+}
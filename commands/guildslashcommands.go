@@ -0,0 +1,388 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/bot"
+	"github.com/jonas747/yagpdb/bot/eventsystem"
+	"github.com/jonas747/yagpdb/commands/models"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/pubsub"
+	"github.com/mediocregopher/radix/v3"
+)
+
+// MaxSlashDescriptions is the max number of custom slash command and option descriptions per server
+const MaxSlashDescriptions = 250
+
+// MaxGuildSlashCommands is the max number of slash commands discord allows on a server,
+// going over it makes the whole bulk overwrite fail
+const MaxGuildSlashCommands = 100
+
+// GuildSlashSettings is the slash commands configuration of a server
+type GuildSlashSettings struct {
+	GuildID int64
+
+	// GuildCommands registers the slash commands on the server itself, which leaves out the commands that are disabled in every channel.
+	// The global ones are still shown next to them, discord doesn't let bots hide global commands on a single server
+	GuildCommands bool
+
+	// Descriptions replaces the descriptions of the guild commands, keyed by the lowercase slash command path (e.g "ban" or "role add"),
+	// options are keyed by the path of their command followed by a colon and the option name (e.g "ban:user")
+	Descriptions map[string]string
+}
+
+// GetGuildSlashSettings returns the slash command settings of the guild, or the defaults if not set
+func GetGuildSlashSettings(ctx context.Context, guildID int64) (*GuildSlashSettings, error) {
+	const q = `SELECT guild_commands, descriptions FROM commands_guild_slash_settings WHERE guild_id=$1;`
+
+	settings := &GuildSlashSettings{
+		GuildID:      guildID,
+		Descriptions: make(map[string]string),
+	}
+
+	var descriptions []byte
+	err := common.PQ.QueryRowContext(ctx, q, guildID).Scan(&settings.GuildCommands, &descriptions)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return settings, nil
+		}
+
+		return nil, err
+	}
+
+	err = json.Unmarshal(descriptions, &settings.Descriptions)
+	return settings, errors.WithMessage(err, "unmarshal descriptions")
+}
+
+// SaveGuildSlashSettings saves the slash command settings of the guild
+func SaveGuildSlashSettings(ctx context.Context, settings *GuildSlashSettings) error {
+	const q = `
+INSERT INTO commands_guild_slash_settings (guild_id, guild_commands, descriptions) VALUES ($1, $2, $3)
+ON CONFLICT (guild_id) DO UPDATE SET guild_commands=$2, descriptions=$3;
+`
+
+	descriptions, err := json.Marshal(settings.Descriptions)
+	if err != nil {
+		return err
+	}
+
+	_, err = common.PQ.ExecContext(ctx, q, settings.GuildID, settings.GuildCommands, descriptions)
+	return err
+}
+
+// ParseSlashDescriptions parses descriptions in the "key = description" per line format used in the control panel
+func ParseSlashDescriptions(in string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	for _, line := range strings.Split(in, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		split := strings.SplitN(line, "=", 2)
+		if len(split) != 2 {
+			return nil, errors.NewPlain("Invalid line, should be in the form of `command = description`: " + line)
+		}
+
+		key := strings.ToLower(strings.Join(strings.Fields(split[0]), " "))
+		key = strings.Replace(key, " :", ":", -1)
+		key = strings.Replace(key, ": ", ":", -1)
+		description := strings.TrimSpace(split[1])
+		if key == "" || description == "" {
+			return nil, errors.NewPlain("Missing command or description: " + line)
+		}
+
+		if len(description) > 100 {
+			return nil, errors.NewPlain("Descriptions can be max 100 characters: " + line)
+		}
+
+		result[key] = description
+	}
+
+	if len(result) > MaxSlashDescriptions {
+		return nil, errors.NewPlain("Too many descriptions")
+	}
+
+	return result, nil
+}
+
+// FormatSlashDescriptions is the inverse of ParseSlashDescriptions
+func FormatSlashDescriptions(descriptions map[string]string) string {
+	keys := make([]string, 0, len(descriptions))
+	for k := range descriptions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf strings.Builder
+	for _, k := range keys {
+		buf.WriteString(k + " = " + descriptions[k] + "\n")
+	}
+
+	return buf.String()
+}
+
+// applySlashDescriptions replaces the descriptions of the command and its options with the custom ones
+func applySlashDescriptions(req *discordgo.CreateApplicationCommandRequest, descriptions map[string]string) {
+	if d, ok := descriptions[req.Name]; ok {
+		req.Description = d
+	}
+
+	applySlashOptionDescriptions(req.Name, req.Options, descriptions)
+}
+
+func applySlashOptionDescriptions(path string, opts []*discordgo.ApplicationCommandOption, descriptions map[string]string) {
+	for _, opt := range opts {
+		switch opt.Kind {
+		case discordgo.CommandOptionTypeSubCommand, discordgo.CommandOptionTypeSubCommandGroup:
+			subPath := path + " " + opt.Name
+			if d, ok := descriptions[subPath]; ok {
+				opt.Description = d
+			}
+
+			applySlashOptionDescriptions(subPath, opt.Options, descriptions)
+		default:
+			if d, ok := descriptions[path+":"+opt.Name]; ok {
+				opt.Description = d
+			}
+		}
+	}
+}
+
+func keyGuildSlashCommandsHash(guildID int64) string {
+	return "slash_commands_guild_current:" + discordgo.StrID(guildID)
+}
+
+// GuildSlashCommandsProvider is implemented by plugins that register their own slash commands on servers,
+// the commands plugin registers them together with its own as every guild command is overwritten on updates
+type GuildSlashCommandsProvider interface {
+	// GuildSlashCommands returns the slash commands to register on the guild
+	GuildSlashCommands(guildID int64) ([]*discordgo.CreateApplicationCommandRequest, error)
+
+	// HandleGuildSlashCommand is called with the server slash commands the command system does not know of,
+	// and returns true if it was one of the plugin's
+	HandleGuildSlashCommand(evt *eventsystem.EventData) (handled bool)
+}
+
+// IsBuiltinSlashCommandName returns true if the name is taken by one of the built in slash commands
+func IsBuiltinSlashCommandName(name string) bool {
+	for _, v := range CommandSystem.Root.Commands {
+		if cast, ok := v.Command.(*YAGCommand); ok && cast.SlashCommandEnabled && strings.EqualFold(v.Trigger.Names[0], name) {
+			return true
+		}
+	}
+
+	for _, v := range slashCommandsContainers {
+		if strings.EqualFold(v.container.Names[0], name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GuildSlashCommandsLimit returns how many slash commands plugins can register on the server,
+// when it uses guild commands the built in ones takes up part of discord's limit
+func GuildSlashCommandsLimit(ctx context.Context, guildID int64) (int, error) {
+	settings, err := GetGuildSlashSettings(ctx, guildID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !settings.GuildCommands {
+		return MaxGuildSlashCommands, nil
+	}
+
+	// the ones disabled in all channels are left out when registering them, but they can be enabled at any point
+	numBuiltin := len(slashCommandsContainers)
+	for _, v := range CommandSystem.Root.Commands {
+		if cast, ok := v.Command.(*YAGCommand); ok && cast.SlashCommandEnabled {
+			numBuiltin++
+		}
+	}
+
+	if numBuiltin > MaxGuildSlashCommands {
+		return 0, nil
+	}
+
+	return MaxGuildSlashCommands - numBuiltin, nil
+}
+
+// guildSlashCommands returns the slash commands to register on the guild, leaving out the ones disabled in all channels
+func (p *Plugin) guildSlashCommands(guildID int64, overrides []*models.CommandsChannelsOverride, descriptions map[string]string) ([]*discordgo.CreateApplicationCommandRequest, error) {
+	result := make([]*discordgo.CreateApplicationCommandRequest, 0)
+
+	for _, v := range CommandSystem.Root.Commands {
+		cast, ok := v.Command.(*YAGCommand)
+		if !ok || !cast.SlashCommandEnabled {
+			continue
+		}
+
+		enabled, err := cast.enabledInGuild(guildID, cast.Name, overrides)
+		if err != nil {
+			return nil, err
+		}
+
+		if enabled {
+			result = append(result, p.yagCommandToSlashCommand(v))
+		}
+	}
+
+	for _, v := range slashCommandsContainers {
+		req := p.containerToSlashCommand(v)
+
+		// leave out the disabled sub commands
+		filtered := make([]*discordgo.ApplicationCommandOption, 0, len(req.Options))
+		for i, sub := range v.container.Commands {
+			cast := sub.Command.(*YAGCommand)
+			enabled, err := cast.enabledInGuild(guildID, v.container.Names[0]+" "+sub.Trigger.Names[0], overrides)
+			if err != nil {
+				return nil, err
+			}
+
+			if enabled {
+				filtered = append(filtered, req.Options[i])
+			}
+		}
+
+		if len(filtered) > 0 {
+			req.Options = filtered
+			result = append(result, req)
+		}
+	}
+
+	for _, v := range result {
+		applySlashDescriptions(v, descriptions)
+	}
+
+	return result, nil
+}
+
+// enabledInGuild returns false if the command is disabled in every channel of the guild
+func (yc *YAGCommand) enabledInGuild(guildID int64, fullName string, overrides []*models.CommandsChannelsOverride) (bool, error) {
+	ce, err := yc.customEnabled(guildID)
+	if err != nil || !ce {
+		return false, err
+	}
+
+	if yc.HideFromCommandsPage {
+		// can't be toggled
+		return true, nil
+	}
+
+	return IsSlashCommandPermissionCommandEnabled(fullName, overrides), nil
+}
+
+// updateGuildSlashCommands registers the slash commands of the guild if it opted into guild commands,
+// together with the slash commands of other plugins, or removes them if there are none
+func (p *Plugin) updateGuildSlashCommands(guildID int64) error {
+	settings, err := GetGuildSlashSettings(context.Background(), guildID)
+	if err != nil {
+		return errors.WithMessage(err, "GetGuildSlashSettings")
+	}
+
+	var oldHash []byte
+	err = common.RedisPool.Do(radix.Cmd(&oldHash, "GET", keyGuildSlashCommandsHash(guildID)))
+	if err != nil {
+		return err
+	}
+
+	cmds := make([]*discordgo.CreateApplicationCommandRequest, 0)
+	if settings.GuildCommands {
+		overrides, err := GetAllOverrides(context.Background(), guildID)
+		if err != nil {
+			return errors.WithMessage(err, "GetAllOverrides")
+		}
+
+		cmds, err = p.guildSlashCommands(guildID, overrides, settings.Descriptions)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, v := range common.Plugins {
+		provider, ok := v.(GuildSlashCommandsProvider)
+		if !ok {
+			continue
+		}
+
+		pluginCmds, err := provider.GuildSlashCommands(guildID)
+		if err != nil {
+			return errors.WithMessage(err, v.PluginInfo().SysName)
+		}
+
+		cmds = append(cmds, pluginCmds...)
+	}
+
+	if len(cmds) > MaxGuildSlashCommands {
+		// the built in commands are first, so it's the plugin ones (e.g custom commands) that gets left out
+		logger.WithField("guild", guildID).Warnf("Guild has %d slash commands, over the limit of %d, leaving out the last ones", len(cmds), MaxGuildSlashCommands)
+		cmds = cmds[:MaxGuildSlashCommands]
+	}
+
+	encoded, _ := json.Marshal(cmds)
+	hash := sha256.Sum256(encoded)
+	if bytes.Equal(hash[:], oldHash) || (len(cmds) < 1 && len(oldHash) < 1) {
+		return nil
+	}
+
+	logger.WithField("guild", guildID).Info("Guild slash commands changed, updating....")
+
+	_, err = common.BotSession.BulkOverwriteGuildApplicationCommands(common.BotApplication.ID, guildID, cmds)
+	if err != nil {
+		return err
+	}
+
+	if len(cmds) < 1 {
+		return common.RedisPool.Do(radix.Cmd(nil, "DEL", keyGuildSlashCommandsHash(guildID)))
+	}
+
+	return common.RedisPool.Do(radix.FlatCmd(nil, "SET", keyGuildSlashCommandsHash(guildID), hash[:]))
+}
+
+func (p *Plugin) handleGuildCreateSlashCommands(evt *eventsystem.EventData) {
+	guildID := evt.GuildCreate().ID
+	if !evt.HasFeatureFlag(featureFlagHasGuildSlashCmds) {
+		// check if it has commands from other plugins, or opted out while we were down
+		var registered bool
+		err := common.RedisPool.Do(radix.Cmd(&registered, "EXISTS", keyGuildSlashCommandsHash(guildID)))
+		if err != nil || !registered {
+			return
+		}
+	}
+
+	err := p.updateGuildSlashCommands(guildID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed updating guild slash commands")
+	}
+}
+
+func (p *Plugin) handleUpdateGuildSlashCommands(event *pubsub.Event) {
+	if bot.State.GetGuild(event.TargetGuildInt) == nil {
+		// not on this process
+		return
+	}
+
+	err := p.updateGuildSlashCommands(event.TargetGuildInt)
+	if err != nil {
+		logger.WithError(err).WithField("guild", event.TargetGuildInt).Error("failed updating guild slash commands")
+	}
+}
+
+// PubsubSendUpdateGuildSlashCommands tells the bot to update the guild slash commands, call this after changing the
+// slash command settings, command overrides or anything a GuildSlashCommandsProvider registers commands from
+func PubsubSendUpdateGuildSlashCommands(guildID int64) {
+	err := pubsub.Publish("update_guild_slash_commands", guildID, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed sending pubsub for update_guild_slash_commands")
+	}
+}
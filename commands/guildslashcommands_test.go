@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonas747/discordgo"
+)
+
+func TestParseSlashDescriptions(t *testing.T) {
+	parsed, err := ParseSlashDescriptions("Ban = Removes troublemakers\n\n  Role   Add = Gives you a role  \nban : User = Who to remove\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"ban":      "Removes troublemakers",
+		"role add": "Gives you a role",
+		"ban:user": "Who to remove",
+	}
+
+	if len(parsed) != len(expected) {
+		t.Fatalf("got %d descriptions, expected %d: %v", len(parsed), len(expected), parsed)
+	}
+
+	for k, v := range expected {
+		if parsed[k] != v {
+			t.Errorf("%q: got %q, expected %q", k, parsed[k], v)
+		}
+	}
+
+	reparsed, err := ParseSlashDescriptions(FormatSlashDescriptions(parsed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range expected {
+		if reparsed[k] != v {
+			t.Errorf("formatted %q: got %q, expected %q", k, reparsed[k], v)
+		}
+	}
+
+	for _, invalid := range []string{"ban", "= no command", "ban =", "ban = " + strings.Repeat("a", 101)} {
+		if _, err := ParseSlashDescriptions(invalid); err == nil {
+			t.Errorf("%q: expected error", invalid)
+		}
+	}
+}
+
+func TestApplySlashDescriptions(t *testing.T) {
+	req := &discordgo.CreateApplicationCommandRequest{
+		Name:        "role",
+		Description: "role container",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "add",
+				Description: "add a role",
+				Kind:        discordgo.CommandOptionTypeSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{Name: "role", Description: "the role", Kind: discordgo.CommandOptionTypeRole},
+				},
+			},
+			{Name: "remove", Description: "remove a role", Kind: discordgo.CommandOptionTypeSubCommand},
+		},
+	}
+
+	applySlashDescriptions(req, map[string]string{
+		"role":          "Manage your roles",
+		"role add":      "Give yourself a role",
+		"role add:role": "The role to give",
+		"role:add":      "not an option",
+	})
+
+	if req.Description != "Manage your roles" {
+		t.Errorf("command description not replaced: %q", req.Description)
+	}
+
+	if req.Options[0].Description != "Give yourself a role" {
+		t.Errorf("sub command description not replaced: %q", req.Options[0].Description)
+	}
+
+	if req.Options[0].Options[0].Description != "The role to give" {
+		t.Errorf("option description not replaced: %q", req.Options[0].Options[0].Description)
+	}
+
+	if req.Options[1].Description != "remove a role" {
+		t.Errorf("untouched sub command description changed: %q", req.Options[1].Description)
+	}
+}
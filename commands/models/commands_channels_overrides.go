@@ -36,6 +36,7 @@ type CommandsChannelsOverride struct {
 	AutodeleteTriggerDelay  int              `boil:"autodelete_trigger_delay" json:"autodelete_trigger_delay" toml:"autodelete_trigger_delay" yaml:"autodelete_trigger_delay"`
 	RequireRoles            types.Int64Array `boil:"require_roles" json:"require_roles" toml:"require_roles" yaml:"require_roles"`
 	IgnoreRoles             types.Int64Array `boil:"ignore_roles" json:"ignore_roles" toml:"ignore_roles" yaml:"ignore_roles"`
+	Prefix                  string           `boil:"prefix" json:"prefix" toml:"prefix" yaml:"prefix"`
 
 	R *commandsChannelsOverrideR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L commandsChannelsOverrideL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -54,6 +55,7 @@ var CommandsChannelsOverrideColumns = struct {
 	AutodeleteTriggerDelay  string
 	RequireRoles            string
 	IgnoreRoles             string
+	Prefix                  string
 }{
 	ID:                      "id",
 	GuildID:                 "guild_id",
@@ -67,6 +69,7 @@ var CommandsChannelsOverrideColumns = struct {
 	AutodeleteTriggerDelay:  "autodelete_trigger_delay",
 	RequireRoles:            "require_roles",
 	IgnoreRoles:             "ignore_roles",
+	Prefix:                  "prefix",
 }
 
 // Generated where
@@ -121,6 +124,15 @@ func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field,
 func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 var CommandsChannelsOverrideWhere = struct {
 	ID                      whereHelperint64
 	GuildID                 whereHelperint64
@@ -134,6 +146,7 @@ var CommandsChannelsOverrideWhere = struct {
 	AutodeleteTriggerDelay  whereHelperint
 	RequireRoles            whereHelpertypes_Int64Array
 	IgnoreRoles             whereHelpertypes_Int64Array
+	Prefix                  whereHelperstring
 }{
 	ID:                      whereHelperint64{field: "\"commands_channels_overrides\".\"id\""},
 	GuildID:                 whereHelperint64{field: "\"commands_channels_overrides\".\"guild_id\""},
@@ -147,6 +160,7 @@ var CommandsChannelsOverrideWhere = struct {
 	AutodeleteTriggerDelay:  whereHelperint{field: "\"commands_channels_overrides\".\"autodelete_trigger_delay\""},
 	RequireRoles:            whereHelpertypes_Int64Array{field: "\"commands_channels_overrides\".\"require_roles\""},
 	IgnoreRoles:             whereHelpertypes_Int64Array{field: "\"commands_channels_overrides\".\"ignore_roles\""},
+	Prefix:                  whereHelperstring{field: "\"commands_channels_overrides\".\"prefix\""},
 }
 
 // CommandsChannelsOverrideRels is where relationship names are stored.
@@ -170,9 +184,9 @@ func (*commandsChannelsOverrideR) NewStruct() *commandsChannelsOverrideR {
 type commandsChannelsOverrideL struct{}
 
 var (
-	commandsChannelsOverrideAllColumns            = []string{"id", "guild_id", "channels", "channel_categories", "global", "commands_enabled", "autodelete_response", "autodelete_trigger", "autodelete_response_delay", "autodelete_trigger_delay", "require_roles", "ignore_roles"}
+	commandsChannelsOverrideAllColumns            = []string{"id", "guild_id", "channels", "channel_categories", "global", "commands_enabled", "autodelete_response", "autodelete_trigger", "autodelete_response_delay", "autodelete_trigger_delay", "require_roles", "ignore_roles", "prefix"}
 	commandsChannelsOverrideColumnsWithoutDefault = []string{"guild_id", "channels", "channel_categories", "global", "commands_enabled", "autodelete_response", "autodelete_trigger", "autodelete_response_delay", "autodelete_trigger_delay", "require_roles", "ignore_roles"}
-	commandsChannelsOverrideColumnsWithDefault    = []string{"id"}
+	commandsChannelsOverrideColumnsWithDefault    = []string{"id", "prefix"}
 	commandsChannelsOverridePrimaryKeyColumns     = []string{"id"}
 )
 
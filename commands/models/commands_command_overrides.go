@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"emperror.dev/errors"
+	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
 	"github.com/volatiletech/sqlboiler/queries"
 	"github.com/volatiletech/sqlboiler/queries/qm"
@@ -35,6 +36,9 @@ type CommandsCommandOverride struct {
 	AutodeleteTriggerDelay      int               `boil:"autodelete_trigger_delay" json:"autodelete_trigger_delay" toml:"autodelete_trigger_delay" yaml:"autodelete_trigger_delay"`
 	RequireRoles                types.Int64Array  `boil:"require_roles" json:"require_roles" toml:"require_roles" yaml:"require_roles"`
 	IgnoreRoles                 types.Int64Array  `boil:"ignore_roles" json:"ignore_roles" toml:"ignore_roles" yaml:"ignore_roles"`
+	UserCooldown                null.Int          `boil:"user_cooldown" json:"user_cooldown,omitempty" toml:"user_cooldown" yaml:"user_cooldown,omitempty"`
+	GuildCooldown               null.Int          `boil:"guild_cooldown" json:"guild_cooldown,omitempty" toml:"guild_cooldown" yaml:"guild_cooldown,omitempty"`
+	CooldownExemptRoles         types.Int64Array  `boil:"cooldown_exempt_roles" json:"cooldown_exempt_roles" toml:"cooldown_exempt_roles" yaml:"cooldown_exempt_roles"`
 
 	R *commandsCommandOverrideR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L commandsCommandOverrideL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -52,6 +56,9 @@ var CommandsCommandOverrideColumns = struct {
 	AutodeleteTriggerDelay      string
 	RequireRoles                string
 	IgnoreRoles                 string
+	UserCooldown                string
+	GuildCooldown               string
+	CooldownExemptRoles         string
 }{
 	ID:                          "id",
 	GuildID:                     "guild_id",
@@ -64,6 +71,9 @@ var CommandsCommandOverrideColumns = struct {
 	AutodeleteTriggerDelay:      "autodelete_trigger_delay",
 	RequireRoles:                "require_roles",
 	IgnoreRoles:                 "ignore_roles",
+	UserCooldown:                "user_cooldown",
+	GuildCooldown:               "guild_cooldown",
+	CooldownExemptRoles:         "cooldown_exempt_roles",
 }
 
 // Generated where
@@ -89,6 +99,29 @@ func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
 var CommandsCommandOverrideWhere = struct {
 	ID                          whereHelperint64
 	GuildID                     whereHelperint64
@@ -101,6 +134,9 @@ var CommandsCommandOverrideWhere = struct {
 	AutodeleteTriggerDelay      whereHelperint
 	RequireRoles                whereHelpertypes_Int64Array
 	IgnoreRoles                 whereHelpertypes_Int64Array
+	UserCooldown                whereHelpernull_Int
+	GuildCooldown               whereHelpernull_Int
+	CooldownExemptRoles         whereHelpertypes_Int64Array
 }{
 	ID:                          whereHelperint64{field: "\"commands_command_overrides\".\"id\""},
 	GuildID:                     whereHelperint64{field: "\"commands_command_overrides\".\"guild_id\""},
@@ -113,6 +149,9 @@ var CommandsCommandOverrideWhere = struct {
 	AutodeleteTriggerDelay:      whereHelperint{field: "\"commands_command_overrides\".\"autodelete_trigger_delay\""},
 	RequireRoles:                whereHelpertypes_Int64Array{field: "\"commands_command_overrides\".\"require_roles\""},
 	IgnoreRoles:                 whereHelpertypes_Int64Array{field: "\"commands_command_overrides\".\"ignore_roles\""},
+	UserCooldown:                whereHelpernull_Int{field: "\"commands_command_overrides\".\"user_cooldown\""},
+	GuildCooldown:               whereHelpernull_Int{field: "\"commands_command_overrides\".\"guild_cooldown\""},
+	CooldownExemptRoles:         whereHelpertypes_Int64Array{field: "\"commands_command_overrides\".\"cooldown_exempt_roles\""},
 }
 
 // CommandsCommandOverrideRels is where relationship names are stored.
@@ -136,9 +175,9 @@ func (*commandsCommandOverrideR) NewStruct() *commandsCommandOverrideR {
 type commandsCommandOverrideL struct{}
 
 var (
-	commandsCommandOverrideAllColumns            = []string{"id", "guild_id", "commands_channels_overrides_id", "commands", "commands_enabled", "autodelete_response", "autodelete_trigger", "autodelete_response_delay", "autodelete_trigger_delay", "require_roles", "ignore_roles"}
-	commandsCommandOverrideColumnsWithoutDefault = []string{"guild_id", "commands_channels_overrides_id", "commands", "commands_enabled", "autodelete_response", "autodelete_trigger", "autodelete_response_delay", "autodelete_trigger_delay", "require_roles", "ignore_roles"}
-	commandsCommandOverrideColumnsWithDefault    = []string{"id"}
+	commandsCommandOverrideAllColumns            = []string{"id", "guild_id", "commands_channels_overrides_id", "commands", "commands_enabled", "autodelete_response", "autodelete_trigger", "autodelete_response_delay", "autodelete_trigger_delay", "require_roles", "ignore_roles", "user_cooldown", "guild_cooldown", "cooldown_exempt_roles"}
+	commandsCommandOverrideColumnsWithoutDefault = []string{"guild_id", "commands_channels_overrides_id", "commands", "commands_enabled", "autodelete_response", "autodelete_trigger", "autodelete_response_delay", "autodelete_trigger_delay", "require_roles", "ignore_roles", "user_cooldown", "guild_cooldown"}
+	commandsCommandOverrideColumnsWithDefault    = []string{"id", "cooldown_exempt_roles"}
 	commandsCommandOverridePrimaryKeyColumns     = []string{"id"}
 )
 
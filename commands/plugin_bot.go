@@ -16,6 +16,7 @@ import (
 	"github.com/jonas747/yagpdb/bot"
 	"github.com/jonas747/yagpdb/bot/eventsystem"
 	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/pubsub"
 )
 
 var (
@@ -28,6 +29,8 @@ var _ bot.BotStopperHandler = (*Plugin)(nil)
 func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, handleMsgCreate, eventsystem.EventMessageCreate)
 	eventsystem.AddHandlerAsyncLastLegacy(p, handleInteractionCreate, eventsystem.EventInteractionCreate)
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleGuildCreateSlashCommands, eventsystem.EventGuildCreate)
+	pubsub.AddHandler("update_guild_slash_commands", p.handleUpdateGuildSlashCommands, nil)
 
 	// Slash command permissions are currently pretty fucked so can't use them
 	//
@@ -189,6 +192,7 @@ func YAGCommandMiddleware(inner dcmd.RunFunc) dcmd.RunFunc {
 		}
 
 		if resp != nil {
+			go yc.recordDenied(data, resp.Type)
 
 			if resp.Type == ReasonCooldown && data.TriggerType != dcmd.TriggerTypeSlashCommands && data.GuildData != nil {
 				if hasPerms, _ := bot.BotHasPermissionGS(data.GuildData.GS, data.GuildData.CS.ID, discordgo.PermissionAddReactions); hasPerms {
@@ -298,20 +302,49 @@ func handleMsgCreate(evt *eventsystem.EventData) {
 		return
 	}
 
-	prefix := defaultCommandPrefix()
-	if evt.GS != nil && evt.HasFeatureFlag(featureFlagHasCustomPrefix) {
-		var err error
-		prefix, err = GetCommandPrefixRedis(evt.GS.ID)
-		if err != nil {
-			logger.WithError(err).WithField("guild", evt.GS.ID).Error("failed fetching command prefix")
-		}
+	prefix, err := GetCommandPrefixBotEvt(evt)
+	if err != nil {
+		logger.WithError(err).WithField("guild", evt.GS.ID).Error("failed fetching command prefix")
+	}
+
+	if evt.GS != nil && evt.HasFeatureFlag(featureFlagHasAliases) {
+		m = resolveAlias(evt.GS.ID, prefix, m)
 	}
 
-	CommandSystem.CheckMessageWtihPrefetchedPrefix(common.BotSession, evt.MessageCreate(), prefix)
+	CommandSystem.CheckMessageWtihPrefetchedPrefix(common.BotSession, m, prefix)
 	// CommandSystem.HandleMessageCreate(common.BotSession, evt.MessageCreate())
 }
 
+// resolveAlias returns a copy of the message invoking the command instead if the message invokes one of the guild's aliases,
+// the original message is shared with the other handlers so it's left alone
+func resolveAlias(guildID int64, prefix string, m *discordgo.MessageCreate) *discordgo.MessageCreate {
+	aliases, err := BotCachedGetAliases(guildID)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed fetching command aliases")
+		return m
+	}
+
+	content, ok := applyAlias(aliases, prefix, m.Content)
+	if !ok {
+		return m
+	}
+
+	cop := *m.Message
+	cop.Content = content
+	return &discordgo.MessageCreate{Message: &cop}
+}
+
+// GetCommandPrefixBotEvt returns the prefix used in the channel of the event, this is the prefix of the channel override if set, otherwise the server prefix
 func GetCommandPrefixBotEvt(evt *eventsystem.EventData) (string, error) {
+	if evt.GS != nil && evt.HasFeatureFlag(featureFlagHasChannelPrefixes) {
+		if cs := evt.CS(); cs != nil {
+			prefix, err := GetChannelPrefix(evt.GS.ID, cs.ID, cs.ParentID)
+			if err != nil || prefix != "" {
+				return prefix, err
+			}
+		}
+	}
+
 	prefix := defaultCommandPrefix()
 	if evt.GS != nil && evt.HasFeatureFlag(featureFlagHasCustomPrefix) {
 		var err error
@@ -327,6 +360,15 @@ func (p *Plugin) Prefix(data *dcmd.Data) string {
 		return "-"
 	}
 
+	if data.GuildData.CS != nil {
+		prefix, err := GetChannelPrefix(data.GuildData.GS.ID, data.GuildData.CS.ID, data.GuildData.CS.ParentID)
+		if err != nil {
+			logger.WithError(err).Error("Failed retrieving channel commands prefix")
+		} else if prefix != "" {
+			return prefix
+		}
+	}
+
 	prefix, err := GetCommandPrefixRedis(data.GuildData.GS.ID)
 	if err != nil {
 		logger.WithError(err).Error("Failed retrieving commands prefix")
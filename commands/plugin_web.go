@@ -8,18 +8,22 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"emperror.dev/errors"
 	"github.com/jonas747/dcmd/v3"
 	"github.com/jonas747/discordgo"
 	"github.com/jonas747/dstate/v3"
+	"github.com/jonas747/yagpdb/bot/botrest"
 	"github.com/jonas747/yagpdb/commands/models"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/cplogs"
 	"github.com/jonas747/yagpdb/common/featureflags"
+	"github.com/jonas747/yagpdb/common/pubsub"
 	"github.com/jonas747/yagpdb/web"
 	"github.com/mediocregopher/radix/v3"
+	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
 	"github.com/volatiletech/sqlboiler/queries/qm"
 	"github.com/volatiletech/sqlboiler/types"
@@ -38,6 +42,7 @@ type ChannelOverrideForm struct {
 	AutodeleteTriggerDelay  int
 	RequireRoles            []int64 `valid:"role,true"`
 	IgnoreRoles             []int64 `valid:"role,true"`
+	Prefix                  string  `valid:",100"`
 }
 
 type CommandOverrideForm struct {
@@ -49,6 +54,35 @@ type CommandOverrideForm struct {
 	AutodeleteTriggerDelay  int
 	RequireRoles            []int64 `valid:"role,true"`
 	IgnoreRoles             []int64 `valid:"role,true"`
+	SetUserCooldown         bool
+	UserCooldown            int `valid:"0,86400"`
+	SetGuildCooldown        bool
+	GuildCooldown           int     `valid:"0,86400"`
+	CooldownExemptRoles     []int64 `valid:"role,true"`
+}
+
+// cooldowns returns the cooldown overrides, null if not set to use the ones defined on the commands
+func (f *CommandOverrideForm) cooldowns() (user null.Int, guild null.Int) {
+	if f.SetUserCooldown {
+		user = null.IntFrom(f.UserCooldown)
+	}
+
+	if f.SetGuildCooldown {
+		guild = null.IntFrom(f.GuildCooldown)
+	}
+
+	return
+}
+
+type SlashCommandsForm struct {
+	GuildCommands bool
+	Descriptions  string `valid:",30000"`
+}
+
+type AliasForm struct {
+	Name    string `valid:",1,32,trimspace"`
+	Command string `valid:",1,100,trimspace"`
+	Args    string `valid:",500"`
 }
 
 var (
@@ -61,6 +95,11 @@ var (
 	panelLogKeyNewCommandOverride     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "commands_new_command", FormatString: "Updated command settings: Created a new command override"})
 	panelLogKeyUpdatedCommandOverride = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "commands_updated_command", FormatString: "Updated command settings: Updated a command override"})
 	panelLogKeyRemovedCommandOverride = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "commands_removed_command", FormatString: "Updated command settings: Removed a command override"})
+
+	panelLogKeySavedAlias   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "commands_saved_alias", FormatString: "Updated command settings: Saved the alias %s"})
+	panelLogKeyRemovedAlias = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "commands_removed_alias", FormatString: "Updated command settings: Removed a command alias"})
+
+	panelLogKeyUpdatedSlashCommands = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "commands_updated_slash_commands", FormatString: "Updated command settings: Updated the slash command settings"})
 )
 
 func (p *Plugin) InitWeb() {
@@ -100,6 +139,23 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post("/channel_overrides/:channelOverride/command_overrides/:commandsOverride/delete"),
 		web.ControllerPostHandler(ChannelOverrideMiddleware(HandleDeleteCommandOverride), getHandler, nil))
 
+	// Alias handlers
+	subMux.Handle(pat.Post("/aliases/new"), web.ControllerPostHandler(HandleSaveAlias, getHandler, AliasForm{}))
+	subMux.Handle(pat.Post("/aliases/:alias/delete"), web.ControllerPostHandler(HandleDeleteAlias, getHandler, nil))
+
+	subMux.Handle(pat.Post("/slash_commands"), web.ControllerPostHandler(HandleSaveSlashCommands, getHandler, SlashCommandsForm{}))
+
+	// Usage stats
+	web.LoadHTMLTemplate("../../commands/assets/commands_stats.html", "templates/plugins/commands_stats.html")
+	web.AddSidebarItem(web.SidebarCategoryCore, &web.SidebarItem{
+		Name: "Command stats",
+		URL:  "commands/stats",
+		Icon: "fas fa-chart-bar",
+	})
+
+	statsHandler := web.ControllerHandler(HandleCommandStats, "cp_commands_stats")
+	web.CPMux.Handle(pat.Get("/commands/stats"), statsHandler)
+	web.CPMux.Handle(pat.Get("/commands/stats/"), statsHandler)
 }
 
 // Servers the command page with current config
@@ -175,6 +231,23 @@ func HandleCommands(w http.ResponseWriter, r *http.Request) (web.TemplateData, e
 
 	templateData["CommandPrefix"] = prefix
 
+	aliases, err := GetAliases(r.Context(), activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	templateData["Aliases"] = aliases
+	templateData["MaxAliases"] = MaxAliases
+
+	slashSettings, err := GetGuildSlashSettings(r.Context(), activeGuild.ID)
+	if err != nil {
+		return templateData, errors.WithMessage(err, "GetGuildSlashSettings")
+	}
+
+	templateData["SlashSettings"] = slashSettings
+	templateData["SlashDescriptions"] = FormatSlashDescriptions(slashSettings.Descriptions)
+	templateData["MaxSlashDescriptions"] = MaxSlashDescriptions
+
 	templateData["VisibleURL"] = "/manage/" + discordgo.StrID(activeGuild.ID) + "/commands/settings"
 
 	return templateData, nil
@@ -240,6 +313,8 @@ func ChannelOverrideMiddleware(inner func(w http.ResponseWriter, r *http.Request
 
 		tmpl, err := inner(w, r, override)
 		featureflags.MarkGuildDirty(activeGuild.ID)
+		pubsub.EvictCacheSet(cachedChannelPrefixes, activeGuild.ID)
+		PubsubSendUpdateGuildSlashCommands(activeGuild.ID)
 		return tmpl, err
 	}
 }
@@ -276,11 +351,14 @@ func HandleCreateChannelsOverride(w http.ResponseWriter, r *http.Request) (web.T
 		AutodeleteTriggerDelay:  formData.AutodeleteTriggerDelay,
 		RequireRoles:            formData.RequireRoles,
 		IgnoreRoles:             formData.IgnoreRoles,
+		Prefix:                  strings.TrimLeftFunc(formData.Prefix, unicode.IsSpace),
 	}
 
 	err = model.InsertG(r.Context(), boil.Infer())
 	if err == nil {
 		featureflags.MarkGuildDirty(activeGuild.ID)
+		pubsub.EvictCacheSet(cachedChannelPrefixes, activeGuild.ID)
+		PubsubSendUpdateGuildSlashCommands(activeGuild.ID)
 		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyNewChannelOverride))
 	}
 	return templateData, errors.WithMessage(err, "InsertG")
@@ -311,6 +389,10 @@ func HandleUpdateChannelsOverride(w http.ResponseWriter, r *http.Request, curren
 	currentOverride.AutodeleteTriggerDelay = formData.AutodeleteTriggerDelay
 	currentOverride.RequireRoles = formData.RequireRoles
 	currentOverride.IgnoreRoles = formData.IgnoreRoles
+	if !currentOverride.Global {
+		// the global override uses the server prefix
+		currentOverride.Prefix = strings.TrimLeftFunc(formData.Prefix, unicode.IsSpace)
+	}
 
 	_, err = currentOverride.UpdateG(r.Context(), boil.Infer())
 	if err == nil {
@@ -369,7 +451,9 @@ func HandleCreateCommandOverride(w http.ResponseWriter, r *http.Request, channel
 		AutodeleteTriggerDelay:  formData.AutodeleteTriggerDelay,
 		RequireRoles:            formData.RequireRoles,
 		IgnoreRoles:             formData.IgnoreRoles,
+		CooldownExemptRoles:     formData.CooldownExemptRoles,
 	}
+	model.UserCooldown, model.GuildCooldown = formData.cooldowns()
 
 	err = model.InsertG(r.Context(), boil.Infer())
 	if err == nil {
@@ -407,6 +491,8 @@ func HandleUpdateCommandOVerride(w http.ResponseWriter, r *http.Request, channel
 	override.AutodeleteTriggerDelay = formData.AutodeleteTriggerDelay
 	override.RequireRoles = formData.RequireRoles
 	override.IgnoreRoles = formData.IgnoreRoles
+	override.UserCooldown, override.GuildCooldown = formData.cooldowns()
+	override.CooldownExemptRoles = formData.CooldownExemptRoles
 
 	_, err = override.UpdateG(r.Context(), boil.Infer())
 	if err == nil {
@@ -435,6 +521,142 @@ func HandleDeleteCommandOverride(w http.ResponseWriter, r *http.Request, channel
 	return templateData, errors.WithMessage(err, "DeleteG")
 }
 
+func HandleSaveAlias(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	activeGuild, templateData := web.GetBaseCPContextData(r.Context())
+	formData := r.Context().Value(common.ContextKeyParsedForm).(*AliasForm)
+
+	if strings.IndexFunc(formData.Name, unicode.IsSpace) != -1 {
+		return templateData.AddAlerts(web.ErrorAlert("Alias names can't contain spaces")), nil
+	}
+
+	command := FindCommandFullName(formData.Command)
+	if command == "" {
+		return templateData.AddAlerts(web.ErrorAlert("Unknown command: ", formData.Command)), nil
+	}
+
+	existing, err := GetAliases(r.Context(), activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	isUpdate := false
+	for _, v := range existing {
+		if strings.EqualFold(v.Name, formData.Name) {
+			isUpdate = true
+			break
+		}
+	}
+
+	if !isUpdate && len(existing) >= MaxAliases {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d aliases allowed", MaxAliases))), nil
+	}
+
+	alias := &CommandAlias{
+		GuildID: activeGuild.ID,
+		Name:    formData.Name,
+		Command: command,
+		Args:    strings.TrimSpace(formData.Args),
+	}
+
+	err = SaveAlias(r.Context(), alias)
+	if err != nil {
+		return templateData, errors.WithMessage(err, "SaveAlias")
+	}
+
+	featureflags.MarkGuildDirty(activeGuild.ID)
+	pubsub.EvictCacheSet(cachedAliases, activeGuild.ID)
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeySavedAlias, &cplogs.Param{Type: cplogs.ParamTypeString, Value: alias.Name}))
+
+	return templateData, nil
+}
+
+func HandleDeleteAlias(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	activeGuild, templateData := web.GetBaseCPContextData(r.Context())
+
+	id, _ := strconv.ParseInt(pat.Param(r, "alias"), 10, 64)
+	deleted, err := DeleteAlias(r.Context(), activeGuild.ID, id)
+	if err != nil {
+		return templateData, errors.WithMessage(err, "DeleteAlias")
+	}
+
+	if deleted {
+		featureflags.MarkGuildDirty(activeGuild.ID)
+		pubsub.EvictCacheSet(cachedAliases, activeGuild.ID)
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRemovedAlias))
+	}
+
+	return templateData, nil
+}
+
+func HandleSaveSlashCommands(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	activeGuild, templateData := web.GetBaseCPContextData(r.Context())
+	formData := r.Context().Value(common.ContextKeyParsedForm).(*SlashCommandsForm)
+
+	descriptions, err := ParseSlashDescriptions(formData.Descriptions)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert(err.Error())), nil
+	}
+
+	err = SaveGuildSlashSettings(r.Context(), &GuildSlashSettings{
+		GuildID:       activeGuild.ID,
+		GuildCommands: formData.GuildCommands,
+		Descriptions:  descriptions,
+	})
+	if err != nil {
+		return templateData, errors.WithMessage(err, "SaveGuildSlashSettings")
+	}
+
+	featureflags.MarkGuildDirty(activeGuild.ID)
+	PubsubSendUpdateGuildSlashCommands(activeGuild.ID)
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedSlashCommands))
+
+	return templateData, nil
+}
+
+// HandleCommandStats shows the command usage within the last ?days=, defaulting to 7
+func HandleCommandStats(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	activeGuild, templateData := web.GetBaseCPContextData(r.Context())
+
+	maxDays := int(UsageLogRetention.Hours() / 24)
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+	if days < 1 || days > maxDays {
+		days = 7
+	}
+
+	stats, err := GetUsageStats(r.Context(), activeGuild.ID, time.Now().Add(-time.Hour*24*time.Duration(days)), 10)
+	if err != nil {
+		return templateData, errors.WithMessage(err, "GetUsageStats")
+	}
+
+	// show the names of the top users if we can
+	userIDs := make([]int64, 0, len(stats.TopUsers))
+	for _, v := range stats.TopUsers {
+		userIDs = append(userIDs, v.UserID)
+	}
+
+	userNames := make(map[int64]string)
+	if len(userIDs) > 0 {
+		members, err := botrest.GetMembers(activeGuild.ID, userIDs...)
+		if err != nil {
+			web.CtxLogger(r.Context()).WithError(err).Error("failed fetching command stats top users")
+		}
+
+		for _, m := range members {
+			if m != nil && m.User != nil {
+				userNames[m.User.ID] = m.User.Username + "#" + m.User.Discriminator
+			}
+		}
+	}
+
+	templateData["Stats"] = stats
+	templateData["UserNames"] = userNames
+	templateData["Days"] = days
+	templateData["DayOptions"] = []int{1, 7, maxDays}
+	templateData["RetentionDays"] = maxDays
+
+	return templateData, nil
+}
+
 var _ web.PluginWithServerHomeWidget = (*Plugin)(nil)
 
 func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
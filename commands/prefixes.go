@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/jonas747/yagpdb/commands/models"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// channelPrefixes holds the prefixes set on channel overrides
+type channelPrefixes struct {
+	Channels   map[int64]string
+	Categories map[int64]string
+}
+
+// get returns the prefix of the channel, the channel itself takes priority over its category
+func (c *channelPrefixes) get(channelID, parentID int64) string {
+	if p, ok := c.Channels[channelID]; ok {
+		return p
+	}
+
+	if parentID != 0 {
+		return c.Categories[parentID]
+	}
+
+	return ""
+}
+
+func getChannelPrefixes(ctx context.Context, guildID int64) (*channelPrefixes, error) {
+	overrides, err := models.CommandsChannelsOverrides(qm.Where("guild_id = ? AND global=false AND prefix != ''", guildID)).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &channelPrefixes{
+		Channels:   make(map[int64]string),
+		Categories: make(map[int64]string),
+	}
+
+	for _, v := range overrides {
+		for _, c := range v.Channels {
+			result.Channels[c] = v.Prefix
+		}
+
+		for _, c := range v.ChannelCategories {
+			result.Categories[c] = v.Prefix
+		}
+	}
+
+	return result, nil
+}
+
+var cachedChannelPrefixes = common.CacheSet.RegisterSlot("commands_channel_prefixes", nil, int64(0))
+
+// GetChannelPrefix returns the prefix set on the override of the channel or its category,
+// or an empty string if there is none in which case the server prefix is used
+func GetChannelPrefix(guildID, channelID, parentID int64) (string, error) {
+	v, err := cachedChannelPrefixes.GetCustomFetch(guildID, func(key interface{}) (interface{}, error) {
+		return getChannelPrefixes(context.Background(), guildID)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return v.(*channelPrefixes).get(channelID, parentID), nil
+}
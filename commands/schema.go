@@ -45,4 +45,27 @@ CREATE TABLE IF NOT EXISTS commands_command_overrides (
 );
 `, `
 CREATE INDEX IF NOT EXISTS commands_command_groups_channels_override_idx ON commands_command_overrides(commands_channels_overrides_id);
+`, `
+ALTER TABLE commands_channels_overrides ADD COLUMN IF NOT EXISTS prefix TEXT NOT NULL DEFAULT '';
+`, `
+CREATE TABLE IF NOT EXISTS commands_aliases (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+
+	name TEXT NOT NULL,
+	command TEXT NOT NULL,
+	args TEXT NOT NULL
+);
+`, `
+CREATE UNIQUE INDEX IF NOT EXISTS commands_aliases_guild_name_idx ON commands_aliases(guild_id, name);
+`, `
+ALTER TABLE commands_command_overrides ADD COLUMN IF NOT EXISTS user_cooldown INT;
+`, `
+ALTER TABLE commands_command_overrides ADD COLUMN IF NOT EXISTS guild_cooldown INT;
+`, `
+ALTER TABLE commands_command_overrides ADD COLUMN IF NOT EXISTS cooldown_exempt_roles BIGINT[] NOT NULL DEFAULT '{}';
+`, `
+CREATE INDEX CONCURRENTLY IF NOT EXISTS executed_commands_guild_id_created_at_idx ON executed_commands(guild_id, created_at);
+`, `
+CREATE INDEX CONCURRENTLY IF NOT EXISTS executed_commands_created_at_idx ON executed_commands(created_at);
 `}
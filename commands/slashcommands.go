@@ -363,6 +363,14 @@ func handleInteractionCreate(evt *eventsystem.EventData) {
 		return
 	}
 
+	if interaction.GuildID != 0 && !IsBuiltinSlashCommandName(interaction.DataCommand.Name) {
+		for _, v := range common.Plugins {
+			if provider, ok := v.(GuildSlashCommandsProvider); ok && provider.HandleGuildSlashCommand(evt) {
+				return
+			}
+		}
+	}
+
 	// serialized, _ := json.MarshalIndent(interaction.Interaction, "", "  ")
 	// logger.Infof("Got interaction %#v", interaction.Interaction)
 	// fmt.Println(string(serialized))
@@ -223,8 +223,16 @@ func execCmd(tmplCtx *templates.Context, dryRun bool, m *discordgo.MessageCreate
 		runFunc = data.ContainerChain[len(data.ContainerChain)-1-i].BuildMiddlewareChain(runFunc, foundCmd)
 	}
 
-	// Check guild scope cooldown
-	cd, err := cast.GuildScopeCooldownLeft(data.ContainerChain, tmplCtx.GS.ID)
+	// Check guild scope cooldown, using the cooldown set in the command overrides if any
+	var settings *CommandSettings
+	if data.GuildData != nil && data.GuildData.CS != nil {
+		settings, err = cast.GetSettings(data.ContainerChain, data.GuildData.CS.ID, data.GuildData.CS.ParentID, tmplCtx.GS.ID)
+		if err != nil {
+			return "", errors.WithMessage(err, "exec/execadmin, settings")
+		}
+	}
+
+	cd, err := cast.GuildScopeCooldownLeft(data.ContainerChain, settings, tmplCtx.GS.ID)
 	if err != nil {
 		return "", errors.WithStackIf(err)
 	}
@@ -238,7 +246,7 @@ func execCmd(tmplCtx *templates.Context, dryRun bool, m *discordgo.MessageCreate
 		return "", errors.WithMessage(err, "exec/execadmin, run")
 	}
 
-	cast.SetCooldownGuild(data.ContainerChain, tmplCtx.GS.ID)
+	cast.SetCooldownGuild(data.ContainerChain, settings, tmplCtx.GS.ID)
 
 	switch v := resp.(type) {
 	case error:
@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonas747/dcmd/v3"
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/backgroundworkers"
+)
+
+// UsageLogRetention is how long the executed commands are logged for, and the max range of the command stats
+const UsageLogRetention = time.Hour * 24 * 30
+
+func (t CanExecuteType) String() string {
+	switch t {
+	case ReasonError:
+		return "Error checking settings"
+	case ReasonCommandDisabaledSettings:
+		return "Command disabled"
+	case ReasonMissingRole:
+		return "Missing a required role"
+	case ReasonIgnoredRole:
+		return "Has an ignored role"
+	case ReasonUserMissingPerms:
+		return "Member missing permissions"
+	case ReasonBotMissingPerms:
+		return "Bot missing permissions"
+	case ReasonCooldown:
+		return "On cooldown"
+	}
+
+	return "Unknown"
+}
+
+func (yc *YAGCommand) rawCommandAndTriggerType(data *dcmd.Data) (rawCommand string, triggerType string) {
+	if data.TriggerType == dcmd.TriggerTypeSlashCommands {
+		return yc.Name + " (slashcommand)", "slashcommand"
+	}
+
+	return data.TraditionalTriggerData.Message.Content, "message"
+}
+
+func newLoggedExecutedCommand(data *dcmd.Data, cmdFullName, rawCommand, triggerType string) *common.LoggedExecutedCommand {
+	logEntry := &common.LoggedExecutedCommand{
+		UserID:    discordgo.StrID(data.Author.ID),
+		ChannelID: discordgo.StrID(data.ChannelID),
+
+		Command:     cmdFullName,
+		RawCommand:  rawCommand,
+		TriggerType: triggerType,
+		TimeStamp:   time.Now(),
+	}
+
+	if data.GuildData != nil {
+		logEntry.GuildID = discordgo.StrID(data.GuildData.GS.ID)
+	}
+
+	return logEntry
+}
+
+// recordDenied logs a invocation the member was not allowed to run in the executed commands, for the command stats
+func (yc *YAGCommand) recordDenied(data *dcmd.Data, reason CanExecuteType) {
+	if data.GuildData == nil {
+		return
+	}
+
+	rawCommand, triggerType := yc.rawCommandAndTriggerType(data)
+	logEntry := newLoggedExecutedCommand(data, yc.FindNameFromContainerChain(data.ContainerChain), rawCommand, triggerType)
+	logEntry.Status = common.ExecutedCommandStatusDenied
+	logEntry.DeniedReason = int(reason)
+
+	err := common.GORM.Create(logEntry).Error
+	if err != nil {
+		yc.Logger(data).WithError(err).Error("Failed creating command execution log")
+	}
+}
+
+// CommandUsage is the number of times a command was invoked, and how many of those failed.
+// Uses and Slash doesn't include the times members were denied running it, those are only counted in Denied
+type CommandUsage struct {
+	Command string
+	Uses    int
+	Slash   int
+	Errors  int
+	Denied  int
+}
+
+// UserUsage is the number of commands a user invoked
+type UserUsage struct {
+	UserID int64
+	Uses   int
+}
+
+// DenialUsage is the number of times members were denied running a command for the reason
+type DenialUsage struct {
+	Reason CanExecuteType
+	Count  int
+}
+
+// UsageStats is the command usage on a server within a time period
+type UsageStats struct {
+	Since time.Time
+	Total int
+
+	// Sorted by the most used first
+	Commands []*CommandUsage
+	TopUsers []*UserUsage
+	Denials  []*DenialUsage
+}
+
+// GetUsageStats returns the command usage of the guild since the provided time, the top users are limited to topUsers
+func GetUsageStats(ctx context.Context, guildID int64, since time.Time, topUsers int) (*UsageStats, error) {
+	stats := &UsageStats{
+		Since: since,
+	}
+
+	const qCommands = `SELECT command, COUNT(*) FILTER (WHERE status != $4),
+	COUNT(*) FILTER (WHERE trigger_type = 'slashcommand' AND status != $4),
+	COUNT(*) FILTER (WHERE status = $3),
+	COUNT(*) FILTER (WHERE status = $4)
+FROM executed_commands WHERE guild_id = $1 AND created_at > $2
+GROUP BY command ORDER BY COUNT(*) FILTER (WHERE status != $4) DESC;`
+
+	strGuildID := discordgo.StrID(guildID)
+	rows, err := common.PQ.QueryContext(ctx, qCommands, strGuildID, since, common.ExecutedCommandStatusError, common.ExecutedCommandStatusDenied)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c CommandUsage
+		err = rows.Scan(&c.Command, &c.Uses, &c.Slash, &c.Errors, &c.Denied)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.Total += c.Uses
+		stats.Commands = append(stats.Commands, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	const qUsers = `SELECT user_id, COUNT(*) FROM executed_commands WHERE guild_id = $1 AND created_at > $2 AND status != $4
+GROUP BY user_id ORDER BY COUNT(*) DESC LIMIT $3;`
+
+	userRows, err := common.PQ.QueryContext(ctx, qUsers, strGuildID, since, topUsers, common.ExecutedCommandStatusDenied)
+	if err != nil {
+		return nil, err
+	}
+	defer userRows.Close()
+
+	for userRows.Next() {
+		var u UserUsage
+		err = userRows.Scan(&u.UserID, &u.Uses)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.TopUsers = append(stats.TopUsers, &u)
+	}
+
+	if err = userRows.Err(); err != nil {
+		return nil, err
+	}
+
+	const qDenials = `SELECT denied_reason, COUNT(*) FROM executed_commands WHERE guild_id = $1 AND created_at > $2 AND status = $3
+GROUP BY denied_reason ORDER BY COUNT(*) DESC;`
+
+	denialRows, err := common.PQ.QueryContext(ctx, qDenials, strGuildID, since, common.ExecutedCommandStatusDenied)
+	if err != nil {
+		return nil, err
+	}
+	defer denialRows.Close()
+
+	for denialRows.Next() {
+		var d DenialUsage
+		err = denialRows.Scan(&d.Reason, &d.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.Denials = append(stats.Denials, &d)
+	}
+
+	return stats, denialRows.Err()
+}
+
+var _ backgroundworkers.BackgroundWorkerPlugin = (*Plugin)(nil)
+
+// RunBackgroundWorker implements backgroundworkers.BackgroundWorkerPlugin
+func (p *Plugin) RunBackgroundWorker() {
+	ticker := time.NewTicker(time.Hour)
+	for {
+		select {
+		case wg := <-p.stopBGWorker:
+			wg.Done()
+			return
+		case <-ticker.C:
+		}
+
+		err := cleanupUsageLog()
+		if err != nil {
+			logger.WithError(err).Error("failed cleaning up old command usage")
+		}
+	}
+}
+
+// StopBackgroundWorker implements backgroundworkers.BackgroundWorkerPlugin
+func (p *Plugin) StopBackgroundWorker(wg *sync.WaitGroup) {
+	p.stopBGWorker <- wg
+}
+
+// cleanupUsageLog deletes the executed commands older than UsageLogRetention, in batches to not lock up the table
+func cleanupUsageLog() error {
+	const batchSize = 10000
+	const q = `DELETE FROM executed_commands WHERE id IN (SELECT id FROM executed_commands WHERE created_at < $1 LIMIT $2);`
+
+	before := time.Now().Add(-UsageLogRetention)
+	for {
+		result, err := common.PQ.Exec(q, before, batchSize)
+		if err != nil {
+			return err
+		}
+
+		if n, _ := result.RowsAffected(); n < batchSize {
+			return nil
+		}
+	}
+}
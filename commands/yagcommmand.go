@@ -181,16 +181,7 @@ func (yc *YAGCommand) Run(data *dcmd.Data) (interface{}, error) {
 
 	// Track how long execution of a command took
 	started := time.Now()
-	rawCommand := ""
-	triggerType := ""
-	switch data.TriggerType {
-	case dcmd.TriggerTypeSlashCommands:
-		rawCommand = yc.Name + " (slashcommand)"
-		triggerType = "slashcommand"
-	default:
-		rawCommand = data.TraditionalTriggerData.Message.Content
-		triggerType = "message"
-	}
+	rawCommand, triggerType := yc.rawCommandAndTriggerType(data)
 	defer func() {
 		yc.logExecutionTime(time.Since(started), rawCommand, data.Author.Username)
 	}()
@@ -207,19 +198,7 @@ func (yc *YAGCommand) Run(data *dcmd.Data) (interface{}, error) {
 	}
 
 	// Set up log entry for later use
-	logEntry := &common.LoggedExecutedCommand{
-		UserID:    discordgo.StrID(data.Author.ID),
-		ChannelID: discordgo.StrID(data.ChannelID),
-
-		Command:    cmdFullName,
-		RawCommand: rawCommand,
-		TimeStamp:  time.Now(),
-	}
-
-	if data.GuildData != nil {
-		logEntry.GuildID = discordgo.StrID(data.GuildData.GS.ID)
-
-	}
+	logEntry := newLoggedExecutedCommand(data, cmdFullName, rawCommand, triggerType)
 
 	metricsExcecutedCommands.With(prometheus.Labels{"name": "(other)", "trigger_type": triggerType}).Inc()
 
@@ -233,6 +212,8 @@ func (yc *YAGCommand) Run(data *dcmd.Data) (interface{}, error) {
 	logEntry.ResponseTime = int64(time.Since(started))
 
 	if cmdErr != nil {
+		logEntry.Status = common.ExecutedCommandStatusError
+
 		if errors.Cause(cmdErr) == context.Canceled || errors.Cause(cmdErr) == context.DeadlineExceeded {
 			r = &EphemeralOrGuild{Content: "Took longer than " + CommandExecTimeout.String() + " to handle command: `" + rawCommand + "`, Cancelled the command."}
 		}
@@ -246,10 +227,13 @@ func (yc *YAGCommand) Run(data *dcmd.Data) (interface{}, error) {
 			cmdErr = nil
 		}
 	} else {
-		// set cooldowns
-		err := yc.SetCooldowns(data.ContainerChain, data.Author.ID, guildID)
-		if err != nil {
-			logger.WithError(err).Error("Failed setting cooldown")
+		// set cooldowns, unless the member is exempt from them
+		settings, _ := data.Context().Value(CtxKeyCmdSettings).(*CommandSettings)
+		if settings == nil || data.GuildData == nil || !settings.IsCooldownExempt(data.GuildData.MS.Member.Roles) {
+			err := yc.SetCooldowns(data.ContainerChain, settings, data.Author.ID, guildID)
+			if err != nil {
+				logger.WithError(err).Error("Failed setting cooldown")
+			}
 		}
 
 		if yc.Plugin != nil {
@@ -447,17 +431,24 @@ func (yc *YAGCommand) checkCanExecuteCommand(data *dcmd.Data) (canExecute bool,
 		}
 	} else {
 		settings = &CommandSettings{
-			Enabled: true,
+			Enabled:       true,
+			UserCooldown:  yc.Cooldown,
+			GuildCooldown: yc.GuildScopeCooldown,
 		}
 	}
 
 	guildID := int64(0)
 	if data.GuildData != nil {
 		guildID = data.GuildData.GS.ID
+
+		if settings.IsCooldownExempt(data.GuildData.MS.Member.Roles) {
+			// exempt members don't check the cooldowns at all, the guild scoped cooldown may have been set by someone else
+			return true, nil, settings, nil
+		}
 	}
 
 	// Check the command cooldown
-	cdLeft, err := yc.LongestCooldownLeft(data.ContainerChain, data.Author.ID, guildID)
+	cdLeft, err := yc.LongestCooldownLeft(data.ContainerChain, settings, data.Author.ID, guildID)
 	if err != nil {
 		// Just pretend the cooldown is off...
 		yc.Logger(data).Error("Failed checking command cooldown")
@@ -681,6 +672,18 @@ type CommandSettings struct {
 
 	RequiredRoles []int64
 	IgnoreRoles   []int64
+
+	// The cooldowns in seconds, these are the ones defined on the command unless raised by a command override
+	UserCooldown  int
+	GuildCooldown int
+
+	// Members with one of these roles are not affected by the cooldowns, they neither check nor set them
+	CooldownExemptRoles []int64
+}
+
+// IsCooldownExempt returns true if one of the roles is exempt from the cooldowns
+func (s *CommandSettings) IsCooldownExempt(roles []int64) bool {
+	return common.ContainsInt64SliceOneOf(s.CooldownExemptRoles, roles)
 }
 
 func GetOverridesForChannel(channelID, channelParentID, guildID int64) ([]*models.CommandsChannelsOverride, error) {
@@ -707,7 +710,10 @@ func (cs *YAGCommand) GetSettings(containerChain []*dcmd.Container, channelID, c
 }
 
 func (cs *YAGCommand) GetSettingsWithLoadedOverrides(containerChain []*dcmd.Container, guildID int64, channelOverrides []*models.CommandsChannelsOverride) (settings *CommandSettings, err error) {
-	settings = &CommandSettings{}
+	settings = &CommandSettings{
+		UserCooldown:  cs.Cooldown,
+		GuildCooldown: cs.GuildScopeCooldown,
+	}
 
 	// Some commands have custom places to toggle their enabled status
 	ce, err := cs.customEnabled(guildID)
@@ -787,20 +793,39 @@ OUTER:
 				settings.DelResponseDelay = cmdOverride.AutodeleteResponseDelay
 				settings.DelTriggerDelay = cmdOverride.AutodeleteTriggerDelay
 
+				// cooldowns not set in the override are left as they are, and they can only be raised
+				// as some of the built in ones protect against abuse or external api limits
+				if cmdOverride.UserCooldown.Valid && cmdOverride.UserCooldown.Int > cs.Cooldown {
+					settings.UserCooldown = cmdOverride.UserCooldown.Int
+				}
+				if cmdOverride.GuildCooldown.Valid && cmdOverride.GuildCooldown.Int > cs.GuildScopeCooldown {
+					settings.GuildCooldown = cmdOverride.GuildCooldown.Int
+				}
+				settings.CooldownExemptRoles = cmdOverride.CooldownExemptRoles
+
 				break OUTER
 			}
 		}
 	}
 }
 
+// cooldowns returns the user and guild scoped cooldowns, if settings is nil the ones defined in the struct is used
+func (cs *YAGCommand) cooldowns(settings *CommandSettings) (user int, guild int) {
+	if settings == nil {
+		return cs.Cooldown, cs.GuildScopeCooldown
+	}
+
+	return settings.UserCooldown, settings.GuildCooldown
+}
+
 // LongestCooldownLeft returns the longest cooldown for this command, either user scoped or guild scoped
-func (cs *YAGCommand) LongestCooldownLeft(cc []*dcmd.Container, userID int64, guildID int64) (int, error) {
-	cdUser, err := cs.UserScopeCooldownLeft(cc, userID)
+func (cs *YAGCommand) LongestCooldownLeft(cc []*dcmd.Container, settings *CommandSettings, userID int64, guildID int64) (int, error) {
+	cdUser, err := cs.UserScopeCooldownLeft(cc, settings, userID)
 	if err != nil {
 		return 0, err
 	}
 
-	cdGuild, err := cs.GuildScopeCooldownLeft(cc, guildID)
+	cdGuild, err := cs.GuildScopeCooldownLeft(cc, settings, guildID)
 	if err != nil {
 		return 0, err
 	}
@@ -813,8 +838,8 @@ func (cs *YAGCommand) LongestCooldownLeft(cc []*dcmd.Container, userID int64, gu
 }
 
 // UserScopeCooldownLeft returns the number of seconds before a command can be used again by this user
-func (cs *YAGCommand) UserScopeCooldownLeft(cc []*dcmd.Container, userID int64) (int, error) {
-	if cs.Cooldown < 1 {
+func (cs *YAGCommand) UserScopeCooldownLeft(cc []*dcmd.Container, settings *CommandSettings, userID int64) (int, error) {
+	if cd, _ := cs.cooldowns(settings); cd < 1 {
 		return 0, nil
 	}
 
@@ -828,8 +853,8 @@ func (cs *YAGCommand) UserScopeCooldownLeft(cc []*dcmd.Container, userID int64)
 }
 
 // GuildScopeCooldownLeft returns the number of seconds before a command can be used again on this server
-func (cs *YAGCommand) GuildScopeCooldownLeft(cc []*dcmd.Container, guildID int64) (int, error) {
-	if cs.GuildScopeCooldown < 1 {
+func (cs *YAGCommand) GuildScopeCooldownLeft(cc []*dcmd.Container, settings *CommandSettings, guildID int64) (int, error) {
+	if _, cd := cs.cooldowns(settings); cd < 1 {
 		return 0, nil
 	}
 
@@ -843,13 +868,13 @@ func (cs *YAGCommand) GuildScopeCooldownLeft(cc []*dcmd.Container, guildID int64
 }
 
 // SetCooldowns is a helper that serts both User and Guild cooldown
-func (cs *YAGCommand) SetCooldowns(cc []*dcmd.Container, userID int64, guildID int64) error {
-	err := cs.SetCooldownUser(cc, userID)
+func (cs *YAGCommand) SetCooldowns(cc []*dcmd.Container, settings *CommandSettings, userID int64, guildID int64) error {
+	err := cs.SetCooldownUser(cc, settings, userID)
 	if err != nil {
 		return errors.WithStackIf(err)
 	}
 
-	err = cs.SetCooldownGuild(cc, guildID)
+	err = cs.SetCooldownGuild(cc, settings, guildID)
 	if err != nil {
 		return errors.WithStackIf(err)
 	}
@@ -857,25 +882,27 @@ func (cs *YAGCommand) SetCooldowns(cc []*dcmd.Container, userID int64, guildID i
 	return nil
 }
 
-// SetCooldownUser sets the user scoped cooldown of the command as it's defined in the struct
-func (cs *YAGCommand) SetCooldownUser(cc []*dcmd.Container, userID int64) error {
-	if cs.Cooldown < 1 {
+// SetCooldownUser sets the user scoped cooldown of the command as it's defined in the settings
+func (cs *YAGCommand) SetCooldownUser(cc []*dcmd.Container, settings *CommandSettings, userID int64) error {
+	cd, _ := cs.cooldowns(settings)
+	if cd < 1 {
 		return nil
 	}
 	now := time.Now().Unix()
 
-	err := common.RedisPool.Do(radix.FlatCmd(nil, "SET", RKeyCommandCooldown(userID, cs.FindNameFromContainerChain(cc)), now, "EX", cs.Cooldown))
+	err := common.RedisPool.Do(radix.FlatCmd(nil, "SET", RKeyCommandCooldown(userID, cs.FindNameFromContainerChain(cc)), now, "EX", cd))
 	return errors.WithStackIf(err)
 }
 
-// SetCooldownGuild sets the guild scoped cooldown of the command as it's defined in the struct
-func (cs *YAGCommand) SetCooldownGuild(cc []*dcmd.Container, guildID int64) error {
-	if cs.GuildScopeCooldown < 1 {
+// SetCooldownGuild sets the guild scoped cooldown of the command as it's defined in the settings
+func (cs *YAGCommand) SetCooldownGuild(cc []*dcmd.Container, settings *CommandSettings, guildID int64) error {
+	_, cd := cs.cooldowns(settings)
+	if cd < 1 {
 		return nil
 	}
 
 	now := time.Now().Unix()
-	err := common.RedisPool.Do(radix.FlatCmd(nil, "SET", RKeyCommandCooldownGuild(guildID, cs.FindNameFromContainerChain(cc)), now, "EX", cs.GuildScopeCooldown))
+	err := common.RedisPool.Do(radix.FlatCmd(nil, "SET", RKeyCommandCooldownGuild(guildID, cs.FindNameFromContainerChain(cc)), now, "EX", cd))
 	return errors.WithStackIf(err)
 }
 
@@ -0,0 +1,108 @@
+package mqueue
+
+import (
+	"bytes"
+	"database/sql"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/lib/pq"
+)
+
+// The blob store holds the contents of the files attached to queued messages,
+// so we don't have to keep them in redis together with the rest of the queue
+
+const (
+	// max total size of the files of a single element
+	MaxFilesSize = 8000000
+
+	// blobs are normally deleted once the message is sent, this is for the ones that wasn't (failed or never processed)
+	blobExpiry = time.Hour * 24 * 7
+)
+
+var ErrBlobNotFound = errors.New("attachment not found, it may have expired")
+
+// storeFiles moves the contents of the files into the blob store
+func storeFiles(files []*QueuedFile) error {
+	total := 0
+	for _, v := range files {
+		total += len(v.Data)
+	}
+
+	if total > MaxFilesSize {
+		return errors.New("files too big")
+	}
+
+	const query = `INSERT INTO mqueue_blobs (created_at, data) VALUES (now(), $1) RETURNING id;`
+
+	for _, v := range files {
+		if v.Data == nil {
+			// already stored
+			continue
+		}
+
+		err := common.PQ.QueryRow(query, v.Data).Scan(&v.BlobID)
+		if err != nil {
+			return errors.WrapIf(err, "mqueue.store_blob")
+		}
+
+		v.Data = nil
+	}
+
+	return nil
+}
+
+// loadFiles returns the files of the element ready to be sent
+func loadFiles(files []*QueuedFile) ([]*discordgo.File, error) {
+	if len(files) < 1 {
+		return nil, nil
+	}
+
+	const query = `SELECT data FROM mqueue_blobs WHERE id=$1;`
+
+	result := make([]*discordgo.File, 0, len(files))
+	for _, v := range files {
+		var data []byte
+		err := common.PQ.QueryRow(query, v.BlobID).Scan(&data)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, ErrBlobNotFound
+			}
+
+			return nil, errors.WrapIf(err, "mqueue.load_blob")
+		}
+
+		result = append(result, &discordgo.File{
+			Name:        v.Name,
+			ContentType: v.ContentType,
+			Reader:      bytes.NewReader(data),
+		})
+	}
+
+	return result, nil
+}
+
+func deleteFiles(files []*QueuedFile) {
+	if len(files) < 1 {
+		return
+	}
+
+	ids := make([]int64, 0, len(files))
+	for _, v := range files {
+		ids = append(ids, v.BlobID)
+	}
+
+	_, err := common.PQ.Exec(`DELETE FROM mqueue_blobs WHERE id = ANY($1);`, pq.Int64Array(ids))
+	if err != nil {
+		logger.WithError(err).Error("failed deleting blobs")
+	}
+}
+
+func cleanupBlobs() {
+	_, err := common.PQ.Exec(`DELETE FROM mqueue_blobs WHERE created_at < $1;`, time.Now().Add(-blobExpiry))
+	if err != nil {
+		logger.WithError(err).Error("failed cleaning up expired blobs")
+	}
+}
@@ -104,6 +104,7 @@ func startPolling() {
 	first := true
 
 	ticker := time.NewTicker(time.Second * 5)
+	blobCleanupTicker := time.NewTicker(time.Hour)
 	for {
 		select {
 		case wg := <-stopChan:
@@ -119,6 +120,8 @@ func startPolling() {
 			l := len(workSlice)
 			workmu.Unlock()
 			metricsQueueSize.Set(float64(l))
+		case <-blobCleanupTicker.C:
+			go cleanupBlobs()
 		}
 	}
 }
@@ -190,10 +193,12 @@ func pollRedis(first bool) {
 
 		}
 
-		sort.Slice(workSlice, func(i, j int) bool {
+		sort.SliceStable(workSlice, func(i, j int) bool {
 			return workSlice[i].elem.Priority > workSlice[j].elem.Priority
 		})
 
+		workScheduler.prune(workSlice, activeWork)
+
 		return nil
 	}))
 }
@@ -210,48 +215,7 @@ var (
 )
 
 func findWork() int {
-	// find a work item that does not share a channel with any other item being processed (so ratelimits only take up max 1 worker)
-OUTER:
-	for i, v := range workSlice {
-		for _, active := range activeWork {
-			if active.elem.Channel == v.elem.Channel {
-				continue OUTER
-			}
-		}
-
-		// Skip channels we have already skipped over
-		for j, w := range workSlice {
-			if j >= i {
-				break
-			}
-
-			if w.elem.Channel == v.elem.Channel {
-				continue OUTER
-			}
-		}
-
-		// check the ratelimit for this channel, we skip elements being ratelimited
-		var ratelimitWait time.Duration
-		if v.elem.UseWebhook {
-			// b := webhookSession.Ratelimiter.GetBucket(discordgo.EndpointWebhook(v.elem.Channel))
-			// b.Lock()
-			// ratelimitWait = webhookSession.Ratelimiter.GetWaitTime(b, 1)
-			// b.Unlock()
-		} else {
-			b := common.BotSession.Ratelimiter.GetBucket(discordgo.EndpointChannelMessages(v.elem.Channel))
-			b.Lock()
-			ratelimitWait = common.BotSession.Ratelimiter.GetWaitTime(b, 1)
-			b.Unlock()
-		}
-
-		if ratelimitWait > time.Millisecond*250 {
-			continue
-		}
-
-		return i
-	}
-
-	return -1
+	return workScheduler.pick(workSlice, activeWork, isRatelimited)
 }
 
 func processWorker() {
@@ -262,7 +226,7 @@ func processWorker() {
 	for {
 		workmu.Lock()
 
-		// find a work item that does not share a channel with any other item being processed (so ratelimits only take up max 1 worker)
+		// find the next work item, see scheduler.go
 		workItemIndex := findWork()
 
 		// did not find any
@@ -273,6 +237,7 @@ func processWorker() {
 		}
 
 		currentItem = workSlice[workItemIndex]
+		workScheduler.markServed(currentItem.elem)
 		activeWork = append(activeWork, currentItem)
 		workSlice = append(workSlice[:workItemIndex], workSlice[workItemIndex+1:]...)
 		workmu.Unlock()
@@ -314,6 +279,7 @@ func process(elem *QueuedElement, raw []byte) {
 		common.RedisPool.Do(radix.Cmd(nil, "ZREM", "mqueue", string(raw)))
 	}()
 
+	attempts := 0
 	for {
 		var err error
 		if elem.UseWebhook {
@@ -322,6 +288,12 @@ func process(elem *QueuedElement, raw []byte) {
 			err = trySendNormal(queueLogger, elem)
 		}
 		if err == nil {
+			deleteFiles(elem.Files)
+			break
+		}
+
+		if errors.Is(err, ErrBlobNotFound) {
+			storeDeadLetter(elem, err)
 			break
 		}
 
@@ -331,22 +303,32 @@ func process(elem *QueuedElement, raw []byte) {
 					maybeDisableFeed(source, elem, e)
 				}
 
+				storeDeadLetter(elem, err)
 				break
 			}
 		} else {
-			if onGuild, err := common.BotIsOnGuild(elem.Guild); !onGuild {
+			if onGuild, guildErr := common.BotIsOnGuild(elem.Guild); !onGuild {
 				if source, ok := sources[elem.Source]; ok {
-					logger.WithError(err).Warnf("disabling feed item %s from %s to nonexistant guild", elem.SourceID, elem.Source)
-					source.DisableFeed(elem, err)
+					logger.WithError(guildErr).Warnf("disabling feed item %s from %s to nonexistant guild", elem.SourceID, elem.Source)
+					source.DisableFeed(elem, guildErr)
 				}
 
+				storeDeadLetter(elem, err)
 				break
-			} else if err != nil {
-				logger.WithError(err).Error("failed checking if bot is on guild")
+			} else if guildErr != nil {
+				logger.WithError(guildErr).Error("failed checking if bot is on guild")
 			}
 		}
 
 		if c, _ := common.DiscordError(err); c != 0 {
+			storeDeadLetter(elem, err)
+			break
+		}
+
+		attempts++
+		if attempts >= confMaxRetries.GetInt() {
+			queueLogger.WithError(err).Error("Giving up sending message after max retries")
+			storeDeadLetter(elem, err)
 			break
 		}
 
@@ -383,30 +365,71 @@ func maybeDisableFeed(source PluginWithSourceDisabler, elem *QueuedElement, err
 }
 
 func trySendNormal(l *logrus.Entry, elem *QueuedElement) (err error) {
-	if elem.MessageStr != "" {
-		_, err = common.BotSession.ChannelMessageSendComplex(elem.Channel, &discordgo.MessageSend{
-			Content:         elem.MessageStr,
-			AllowedMentions: elem.AllowedMentions,
-		})
-	} else if elem.MessageEmbed != nil {
-		_, err = common.BotSession.ChannelMessageSendEmbed(elem.Channel, elem.MessageEmbed)
-	} else {
+	if elem.IsEmpty() {
 		l.Error("Both MessageEmbed and MessageStr empty")
+		return
 	}
 
-	return
+	files, err := loadFiles(elem.Files)
+	if err != nil {
+		return err
+	}
+
+	embeds := elem.AllEmbeds()
+	var embed *discordgo.MessageEmbed
+	if len(embeds) > 0 {
+		embed = embeds[0]
+		embeds = embeds[1:]
+	}
+
+	m, err := common.BotSession.ChannelMessageSendComplex(elem.Channel, &discordgo.MessageSend{
+		Content:         elem.MessageStr,
+		Embed:           embed,
+		Files:           files,
+		AllowedMentions: elem.AllowedMentions,
+	})
+	if err != nil {
+		return err
+	}
+
+	if elem.MessageIDKey != "" {
+		storeErr := common.RedisPool.Do(radix.FlatCmd(nil, "SET", elem.MessageIDKey, m.ID, "EX", messageIDExpiry))
+		if storeErr != nil {
+			l.WithError(storeErr).Error("failed storing message id")
+		}
+	}
+
+	// bot messages can only have a single embed, the first message was sent so don't return errors from here on
+	// as that would send it again
+	for _, v := range embeds {
+		_, err := common.BotSession.ChannelMessageSendEmbed(elem.Channel, v)
+		if err != nil {
+			l.WithError(err).Error("failed sending additional embed")
+			break
+		}
+	}
+
+	return nil
 }
 
+// how long the message ids of sent messages are kept around for editing
+const messageIDExpiry = 60 * 60 * 24 * 7
+
 type cacheKeyWebhook int64
 
 var errGuildNotFound = errors.New("Guild not found")
 
 func trySendWebhook(l *logrus.Entry, elem *QueuedElement) (err error) {
-	if elem.MessageStr == "" && elem.MessageEmbed == nil {
+	if elem.IsEmpty() {
 		l.Error("Both MessageEmbed and MessageStr empty")
 		return
 	}
 
+	files, err := loadFiles(elem.Files)
+	if err != nil {
+		return err
+	}
+
 	// find the avatar, this is slightly expensive, do i need to rethink this?
 	avatar := ""
 	if source, ok := sources[elem.Source]; ok {
@@ -434,16 +457,36 @@ func trySendWebhook(l *logrus.Entry, elem *QueuedElement) (err error) {
 
 	wh := whI.(*webhook)
 
+	var file *discordgo.File
+	if len(files) > 0 {
+		file = files[0]
+		files = files[1:]
+	}
+
 	webhookParams := &discordgo.WebhookParams{
-		Username: elem.WebhookUsername,
-		Content:  elem.MessageStr,
+		Username:        elem.WebhookUsername,
+		Content:         elem.MessageStr,
+		Embeds:          elem.AllEmbeds(),
+		File:            file,
+		AllowedMentions: &elem.AllowedMentions,
 	}
 
-	if elem.MessageEmbed != nil {
-		webhookParams.Embeds = []*discordgo.MessageEmbed{elem.MessageEmbed}
+	_, err = webhookSession.WebhookExecuteComplex(wh.ID, wh.Token, true, webhookParams)
+	if err == nil {
+		// webhook messages can only have a single file, the first message was sent so don't return errors from here on
+		// as that would send it again
+		for _, v := range files {
+			_, err := webhookSession.WebhookExecuteComplex(wh.ID, wh.Token, true, &discordgo.WebhookParams{
+				Username: elem.WebhookUsername,
+				File:     v,
+			})
+			if err != nil {
+				l.WithError(err).Error("failed sending additional file")
+				break
+			}
+		}
 	}
 
-	err = webhookSession.WebhookExecute(wh.ID, wh.Token, true, webhookParams)
 	if code, _ := common.DiscordError(err); code == discordgo.ErrCodeUnknownWebhook {
 		// if the webhook was deleted, then delete the bad boi from the databse and retry
 		const query = `DELETE FROM mqueue_webhooks WHERE id=$1`
@@ -0,0 +1,162 @@
+package mqueue
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/yagpdb/common"
+)
+
+// only the latest dead letters of each guild are kept
+const maxDeadLettersPerGuild = 100
+
+// DeadLetter is a queued element that failed to send, kept around so the guild can see what went wrong and re-queue it
+type DeadLetter struct {
+	ID        int64
+	CreatedAt time.Time
+
+	GuildID   int64
+	ChannelID int64
+
+	Source   string
+	SourceID string
+	Error    string
+
+	Element *QueuedElement
+}
+
+// Preview returns a short preview of the message content
+func (d *DeadLetter) Preview() string {
+	if d.Element.MessageStr != "" {
+		return common.CutStringShort(d.Element.MessageStr, 100)
+	}
+
+	if embeds := d.Element.AllEmbeds(); len(embeds) > 0 {
+		embed := embeds[0]
+		if embed.Title != "" {
+			return common.CutStringShort(embed.Title, 100)
+		}
+
+		return common.CutStringShort(embed.Description, 100)
+	}
+
+	return ""
+}
+
+func storeDeadLetter(elem *QueuedElement, sendErr error) {
+	l := logger.WithField("mq_id", elem.ID).WithField("source", elem.Source).WithField("sourceid", elem.SourceID)
+
+	serialized, err := json.Marshal(elem)
+	if err != nil {
+		l.WithError(err).Error("failed marshaling dead letter")
+		return
+	}
+
+	errStr := "unknown error"
+	if sendErr != nil {
+		errStr = sendErr.Error()
+	}
+
+	const query = `
+INSERT INTO mqueue_dead_letters (created_at, guild_id, channel_id, source, source_id, error, element)
+VALUES (now(), $1, $2, $3, $4, $5, $6);
+`
+
+	_, err = common.PQ.Exec(query, elem.Guild, elem.Channel, elem.Source, elem.SourceID, errStr, serialized)
+	if err != nil {
+		l.WithError(err).Error("failed storing dead letter")
+		return
+	}
+
+	const pruneQuery = `
+DELETE FROM mqueue_dead_letters WHERE guild_id=$1 AND id <= (
+	SELECT id FROM mqueue_dead_letters WHERE guild_id=$1 ORDER BY id DESC OFFSET $2 LIMIT 1
+);
+`
+
+	_, err = common.PQ.Exec(pruneQuery, elem.Guild, maxDeadLettersPerGuild)
+	if err != nil {
+		l.WithError(err).Error("failed pruning dead letters")
+	}
+}
+
+func scanDeadLetter(row interface{ Scan(...interface{}) error }) (*DeadLetter, error) {
+	var d DeadLetter
+	var serialized []byte
+	err := row.Scan(&d.ID, &d.CreatedAt, &d.GuildID, &d.ChannelID, &d.Source, &d.SourceID, &d.Error, &serialized)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(serialized, &d.Element)
+	if err != nil {
+		return nil, errors.WrapIf(err, "json.unmarshal")
+	}
+
+	return &d, nil
+}
+
+// GetDeadLetters returns the latest dead letters of the guild, newest first
+func GetDeadLetters(guildID int64, limit int) ([]*DeadLetter, error) {
+	const query = `
+SELECT id, created_at, guild_id, channel_id, source, source_id, error, element FROM mqueue_dead_letters
+WHERE guild_id=$1 ORDER BY id DESC LIMIT $2;
+`
+
+	rows, err := common.PQ.Query(query, guildID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*DeadLetter, 0)
+	for rows.Next() {
+		d, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, d)
+	}
+
+	return result, rows.Err()
+}
+
+// GetDeadLetter returns the dead letter with the id, or nil if not found
+func GetDeadLetter(guildID int64, id int64) (*DeadLetter, error) {
+	const query = `
+SELECT id, created_at, guild_id, channel_id, source, source_id, error, element FROM mqueue_dead_letters
+WHERE guild_id=$1 AND id=$2;
+`
+
+	d, err := scanDeadLetter(common.PQ.QueryRow(query, guildID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return d, err
+}
+
+// DeleteDeadLetter deletes the dead letter, if id is 0 then all the dead letters of the guild are deleted
+func DeleteDeadLetter(guildID int64, id int64) error {
+	if id == 0 {
+		_, err := common.PQ.Exec(`DELETE FROM mqueue_dead_letters WHERE guild_id=$1;`, guildID)
+		return err
+	}
+
+	_, err := common.PQ.Exec(`DELETE FROM mqueue_dead_letters WHERE guild_id=$1 AND id=$2;`, guildID, id)
+	return err
+}
+
+// RequeueDeadLetter puts the element of the dead letter back into the queue and deletes the dead letter
+func RequeueDeadLetter(d *DeadLetter) error {
+	err := DeleteDeadLetter(d.GuildID, d.ID)
+	if err != nil {
+		return err
+	}
+
+	QueueMessage(d.Element)
+	return nil
+}
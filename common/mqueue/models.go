@@ -21,13 +21,20 @@ type QueuedElement struct {
 	SourceID string
 
 	// The actual message as a simple string
-	// specify only one of MessageStr or MessageEmbed
+	// if both MessageStr and MessageEmbed are set, they're sent in the same message
 	MessageStr string `json:",omitempty"`
 
 	// The actual message as an embed
-	// specify only one of MessageStr or MessageEmbed
 	MessageEmbed *discordgo.MessageEmbed `json:",omitempty"`
 
+	// Additional embeds (max 10 in total), sent in the same message as MessageEmbed when using webhooks,
+	// bot messages can only have a single embed so the rest are sent as follow up messages
+	Embeds []*discordgo.MessageEmbed `json:",omitempty"`
+
+	// Files to attach, the contents are stored in the blob store when queued.
+	// Webhook messages can only have a single file so the rest are sent as follow up messages
+	Files []*QueuedFile `json:",omitempty"`
+
 	UseWebhook      bool
 	WebhookUsername string
 
@@ -35,8 +42,46 @@ type QueuedElement struct {
 
 	// When the queue grows, the feeds with the highest priority gets sent first
 	Priority int
+
+	// If set, the id of the sent message is stored in redis under this key so the source can edit it later,
+	// only supported when not using webhooks
+	MessageIDKey string `json:",omitempty"`
+}
+
+// QueuedFile is a file attached to a queued message
+type QueuedFile struct {
+	Name        string
+	ContentType string
+
+	// The contents of the file, only used when queueing the message, it's moved to the blob store after that
+	Data []byte `json:"-"`
+
+	BlobID int64
+}
+
+// AllEmbeds returns MessageEmbed and Embeds combined
+func (elem *QueuedElement) AllEmbeds() []*discordgo.MessageEmbed {
+	embeds := make([]*discordgo.MessageEmbed, 0, len(elem.Embeds)+1)
+	if elem.MessageEmbed != nil {
+		embeds = append(embeds, elem.MessageEmbed)
+	}
+
+	embeds = append(embeds, elem.Embeds...)
+	if len(embeds) > maxEmbeds {
+		embeds = embeds[:maxEmbeds]
+	}
+
+	return embeds
+}
+
+// IsEmpty returns true if there's nothing to send
+func (elem *QueuedElement) IsEmpty() bool {
+	return elem.MessageStr == "" && elem.MessageEmbed == nil && len(elem.Embeds) < 1 && len(elem.Files) < 1
 }
 
+// max embeds in a single message
+const maxEmbeds = 10
+
 type webhook struct {
 	ID    int64
 	Token string
@@ -20,6 +20,7 @@ var (
 	webhookSession *discordgo.Session
 	logger         = common.GetPluginLogger(&Plugin{})
 	confMaxWorkers = config.RegisterOption("yagpdb.mqueue.max_workers", "Max mqueue sending workers", 2)
+	confMaxRetries = config.RegisterOption("yagpdb.mqueue.max_retries", "Max times a message is retried on non discord errors before it's moved to the dead letter queue", 300)
 )
 
 // PluginWithSourceDisabler
@@ -108,6 +109,12 @@ func QueueMessage(elem *QueuedElement) {
 
 	elem.ID = nextID
 
+	err := storeFiles(elem.Files)
+	if err != nil {
+		logger.WithError(err).Error("Failed storing mqueue element files")
+		return
+	}
+
 	serialized, err := json.Marshal(elem)
 	if err != nil {
 		logger.WithError(err).Error("Failed marshaling mqueue element")
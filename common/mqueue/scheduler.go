@@ -0,0 +1,127 @@
+package mqueue
+
+import (
+	"time"
+
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/common"
+)
+
+// The scheduler picks work round-robin per guild, and then per channel within the guild,
+// so that a single guild with a lot of feeds can't starve the rest of the queue during bursts.
+// Elements with a higher priority are still always sent first.
+//
+// Everything in here is protected by workmu.
+
+// scheduler keeps track of when guilds and channels were last served,
+// a lower value means it was served longer ago (or never)
+type scheduler struct {
+	counter  int64
+	guilds   map[int64]int64
+	channels map[int64]int64
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{
+		guilds:   make(map[int64]int64),
+		channels: make(map[int64]int64),
+	}
+}
+
+var workScheduler = newScheduler()
+
+// markServed should be called when a element is picked for processing
+func (s *scheduler) markServed(elem *QueuedElement) {
+	s.counter++
+	s.guilds[elem.Guild] = s.counter
+	s.channels[elem.Channel] = s.counter
+}
+
+// fairer returns true if a should be processed before b
+func (s *scheduler) fairer(a, b *QueuedElement) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+
+	if ga, gb := s.guilds[a.Guild], s.guilds[b.Guild]; ga != gb {
+		return ga < gb
+	}
+
+	return s.channels[a.Channel] < s.channels[b.Channel]
+}
+
+// pick returns the index of the next item to process, or -1 if there's nothing that can be processed right now
+func (s *scheduler) pick(queue, active []*workItem, ratelimited func(elem *QueuedElement) bool) int {
+	// only one element per channel is processed at a time, so ratelimits only take up max 1 worker
+	blockedChannels := make(map[int64]bool, len(active))
+	for _, v := range active {
+		blockedChannels[v.elem.Channel] = true
+	}
+
+	best := -1
+	for i, v := range queue {
+		if blockedChannels[v.elem.Channel] {
+			continue
+		}
+
+		// elements in the same channel are sent in order, so skip the rest of the channel
+		blockedChannels[v.elem.Channel] = true
+
+		if best != -1 && !s.fairer(v.elem, queue[best].elem) {
+			continue
+		}
+
+		if ratelimited(v.elem) {
+			continue
+		}
+
+		best = i
+	}
+
+	return best
+}
+
+// prune removes the guilds and channels that has nothing queued or being processed,
+// they will be treated as never served the next time they show up which is fine
+func (s *scheduler) prune(queue, active []*workItem) {
+	guilds := make(map[int64]bool)
+	channels := make(map[int64]bool)
+	for _, items := range [][]*workItem{queue, active} {
+		for _, v := range items {
+			guilds[v.elem.Guild] = true
+			channels[v.elem.Channel] = true
+		}
+	}
+
+	for k := range s.guilds {
+		if !guilds[k] {
+			delete(s.guilds, k)
+		}
+	}
+
+	for k := range s.channels {
+		if !channels[k] {
+			delete(s.channels, k)
+		}
+	}
+
+	if len(s.guilds) < 1 {
+		s.counter = 0
+	}
+}
+
+// isRatelimited returns true if the channel of the element is currently ratelimited,
+// using the ratelimits discord told us about in previous responses
+func isRatelimited(elem *QueuedElement) bool {
+	if elem.UseWebhook {
+		// webhooks are not tracked per channel
+		return false
+	}
+
+	b := common.BotSession.Ratelimiter.GetBucket(discordgo.EndpointChannelMessages(elem.Channel))
+	b.Lock()
+	wait := common.BotSession.Ratelimiter.GetWaitTime(b, 1)
+	b.Unlock()
+
+	return wait > time.Millisecond*250
+}
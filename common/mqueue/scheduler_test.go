@@ -0,0 +1,88 @@
+package mqueue
+
+import (
+	"testing"
+)
+
+func testItem(guild, channel int64, priority int) *workItem {
+	return &workItem{elem: &QueuedElement{Guild: guild, Channel: channel, Priority: priority}}
+}
+
+func notRatelimited(elem *QueuedElement) bool { return false }
+
+func TestSchedulerRoundRobin(t *testing.T) {
+	s := newScheduler()
+
+	// guild 1 has a burst of feeds queued before guild 2 and 3
+	queue := []*workItem{
+		testItem(1, 10, 0),
+		testItem(1, 11, 0),
+		testItem(1, 12, 0),
+		testItem(2, 20, 0),
+		testItem(3, 30, 0),
+	}
+
+	var order []int64
+	for len(queue) > 0 {
+		i := s.pick(queue, nil, notRatelimited)
+		if i == -1 {
+			t.Fatal("expected to find work")
+		}
+
+		s.markServed(queue[i].elem)
+		order = append(order, queue[i].elem.Guild)
+		queue = append(queue[:i], queue[i+1:]...)
+	}
+
+	expected := []int64{1, 2, 3, 1, 1}
+	for i, v := range expected {
+		if order[i] != v {
+			t.Fatalf("expected guild order %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestSchedulerPriorityAndChannels(t *testing.T) {
+	s := newScheduler()
+
+	queue := []*workItem{
+		testItem(1, 10, 0),
+		testItem(2, 20, 0),
+		testItem(2, 21, 1),
+	}
+
+	if i := s.pick(queue, nil, notRatelimited); i != 2 {
+		t.Errorf("expected the highest priority item to be picked, got %d", i)
+	}
+
+	// channel 21 is being processed and channel 20 is ratelimited
+	active := []*workItem{testItem(2, 21, 0)}
+	ratelimited := func(elem *QueuedElement) bool { return elem.Channel == 20 }
+	if i := s.pick(queue, active, ratelimited); i != 0 {
+		t.Errorf("expected item 0 to be picked, got %d", i)
+	}
+
+	// elements in the same channel are processed in order
+	queue = []*workItem{
+		testItem(1, 10, 0),
+		testItem(1, 10, 1),
+	}
+	ratelimited = func(elem *QueuedElement) bool { return true }
+	if i := s.pick(queue, nil, ratelimited); i != -1 {
+		t.Errorf("expected no item to be picked, got %d", i)
+	}
+}
+
+func TestSchedulerPrune(t *testing.T) {
+	s := newScheduler()
+	s.markServed(testItem(1, 10, 0).elem)
+	s.markServed(testItem(2, 20, 0).elem)
+
+	s.prune([]*workItem{testItem(2, 20, 0)}, nil)
+	if _, ok := s.guilds[1]; ok {
+		t.Error("expected guild 1 to be pruned")
+	}
+	if _, ok := s.channels[20]; !ok {
+		t.Error("expected channel 20 to be kept")
+	}
+}
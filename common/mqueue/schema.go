@@ -12,4 +12,29 @@ CREATE TABLE IF NOT EXISTS mqueue_webhooks (
 );
 
 CREATE INDEX IF NOT EXISTS mqueue_webhooks_channel_id_idx ON mqueue_webhooks(channel_id);
+
+CREATE TABLE IF NOT EXISTS mqueue_dead_letters (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	guild_id BIGINT NOT NULL,
+	channel_id BIGINT NOT NULL,
+
+	source TEXT NOT NULL,
+	source_id TEXT NOT NULL,
+	error TEXT NOT NULL,
+
+	element JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS mqueue_dead_letters_guild_id_idx ON mqueue_dead_letters(guild_id);
+
+CREATE TABLE IF NOT EXISTS mqueue_blobs (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	data BYTEA NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS mqueue_blobs_created_at_idx ON mqueue_blobs(created_at);
 `
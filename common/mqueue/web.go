@@ -0,0 +1,105 @@
+package mqueue
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/jonas747/yagpdb/common/cplogs"
+	"github.com/jonas747/yagpdb/web"
+	"goji.io"
+	"goji.io/pat"
+)
+
+var (
+	panelLogKeyRequeued      = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "mqueue_requeued_message", FormatString: "Re-queued failed %s message"})
+	panelLogKeyClearedFailed = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "mqueue_cleared_failed", FormatString: "Cleared failed feed messages"})
+)
+
+var _ web.Plugin = (*Plugin)(nil)
+
+func (p *Plugin) InitWeb() {
+	web.LoadHTMLTemplate("../../common/mqueue/assets/mqueue.html", "templates/plugins/mqueue.html")
+	web.AddSidebarItem(web.SidebarCategoryFeeds, &web.SidebarItem{
+		Name: "Failed messages",
+		URL:  "mqueue",
+		Icon: "fas fa-exclamation-triangle",
+	})
+
+	mux := goji.SubMux()
+	web.CPMux.Handle(pat.New("/mqueue/*"), mux)
+	web.CPMux.Handle(pat.New("/mqueue"), mux)
+
+	mainGetHandler := web.ControllerHandler(p.HandleDeadLetters, "cp_mqueue")
+
+	mux.Handle(pat.Get("/"), mainGetHandler)
+	mux.Handle(pat.Get(""), mainGetHandler)
+
+	mux.Handle(pat.Post("/clear"), web.ControllerPostHandler(p.HandleClear, mainGetHandler, nil))
+	mux.Handle(pat.Post("/:item/requeue"), web.ControllerPostHandler(p.HandleRequeue, mainGetHandler, nil))
+	mux.Handle(pat.Post("/:item/delete"), web.ControllerPostHandler(p.HandleDelete, mainGetHandler, nil))
+}
+
+func (p *Plugin) HandleDeadLetters(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	deadLetters, err := GetDeadLetters(ag.ID, maxDeadLettersPerGuild)
+	if err != nil {
+		return templateData, err
+	}
+
+	channelNames := make(map[int64]string)
+	for _, c := range ag.Channels {
+		channelNames[c.ID] = c.Name
+	}
+
+	templateData["DeadLetters"] = deadLetters
+	templateData["ChannelNames"] = channelNames
+	templateData["MaxDeadLetters"] = maxDeadLettersPerGuild
+
+	return templateData, nil
+}
+
+func (p *Plugin) HandleRequeue(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	id, _ := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	deadLetter, err := GetDeadLetter(ag.ID, id)
+	if err != nil {
+		return templateData, err
+	}
+
+	if deadLetter == nil {
+		return templateData.AddAlerts(web.ErrorAlert("Message not found, it may already have been re-queued")), nil
+	}
+
+	err = RequeueDeadLetter(deadLetter)
+	if err != nil {
+		return templateData, err
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRequeued, &cplogs.Param{Type: cplogs.ParamTypeString, Value: deadLetter.Source}))
+
+	return templateData.AddAlerts(web.SucessAlert("Re-queued the message, if the feed was disabled you may have to enable it again")), nil
+}
+
+func (p *Plugin) HandleDelete(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	id, _ := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+	if id == 0 {
+		return templateData, nil
+	}
+
+	return templateData, DeleteDeadLetter(ag.ID, id)
+}
+
+func (p *Plugin) HandleClear(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	err := DeleteDeadLetter(ag.ID, 0)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyClearedFailed))
+	}
+
+	return templateData, err
+}
@@ -0,0 +1,109 @@
+package scheduledevents2
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/scheduledevents2/models"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// MaxListedEvents is the max number of pending events shown in the control panel and the scheduledevents command
+const MaxListedEvents = 100
+
+const cancelledError = "cancelled"
+
+// GetPendingEvents returns the events of the guild that has not been processed yet, the ones triggering first first
+func GetPendingEvents(ctx context.Context, guildID int64, limit int) (models.ScheduledEventSlice, error) {
+	return models.ScheduledEvents(
+		models.ScheduledEventWhere.GuildID.EQ(guildID),
+		models.ScheduledEventWhere.Processed.EQ(false),
+		qm.OrderBy("triggers_at asc, id asc"),
+		qm.Limit(limit)).AllG(ctx)
+}
+
+// IsRegisteredHandler returns true if there's a handler for the event, this is only known on the bot
+func IsRegisteredHandler(eventName string) bool {
+	_, ok := registeredHandlers[eventName]
+	return ok
+}
+
+// EventDataString returns the data of the event as indented json
+func EventDataString(evt *models.ScheduledEvent) string {
+	var buf bytes.Buffer
+	err := json.Indent(&buf, evt.Data, "", "  ")
+	if err != nil {
+		return string(evt.Data)
+	}
+
+	return buf.String()
+}
+
+// CancelEvent marks the event as processed without running it, returns false if it was not found or already processed
+func CancelEvent(ctx context.Context, guildID int64, id int64) (bool, error) {
+	const q = "UPDATE scheduled_events SET processed=true, error=$3 WHERE id=$1 AND guild_id=$2 AND processed=false"
+	result, err := common.PQ.ExecContext(ctx, q, id, guildID, cancelledError)
+	if err != nil {
+		return false, err
+	}
+
+	if n, _ := result.RowsAffected(); n < 1 {
+		return false, nil
+	}
+
+	markDoneRedis(guildID, id)
+	return true, nil
+}
+
+// RunEventNow moves the event up to trigger right away, returns false if it was not found or already processed
+func RunEventNow(ctx context.Context, guildID int64, id int64) (bool, error) {
+	evt, err := models.ScheduledEvents(
+		models.ScheduledEventWhere.ID.EQ(id),
+		models.ScheduledEventWhere.GuildID.EQ(guildID),
+		models.ScheduledEventWhere.Processed.EQ(false)).OneG(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	evt.TriggersAt = time.Now()
+	_, err = evt.UpdateG(ctx, boil.Whitelist("triggers_at"))
+	if err != nil {
+		return false, err
+	}
+
+	// the bot only picks up events from redis, so put it in there right away instead of waiting for the next flush
+	return true, flushEventToRedis(common.RedisPool, evt)
+}
+
+// recordAttempt stores the error of a failed run of a event, and increments the retry counter if it's being retried
+func recordAttempt(id int64, retry bool, runErr error) {
+	if runErr == nil && !retry {
+		return
+	}
+
+	var errStr null.String
+	if runErr != nil {
+		errStr = null.StringFrom(runErr.Error())
+	}
+
+	retries := 0
+	if retry {
+		retries = 1
+	}
+
+	const q = "UPDATE scheduled_events SET retries=retries+$2, error=COALESCE($3, error) WHERE id=$1"
+	_, err := common.PQ.Exec(q, id, retries, errStr)
+	if err != nil {
+		logger.WithError(err).WithField("id", id).Error("failed recording scheduled event attempt")
+	}
+}
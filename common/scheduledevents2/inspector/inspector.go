@@ -0,0 +1,113 @@
+// Package inspector adds a control panel page listing the pending scheduled events of a server,
+// it's separate from scheduledevents2 as the web package depends on it through the templates package
+package inspector
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/cplogs"
+	"github.com/jonas747/yagpdb/common/scheduledevents2"
+	"github.com/jonas747/yagpdb/web"
+	"goji.io"
+	"goji.io/pat"
+)
+
+var (
+	panelLogKeyCancelled = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "scheduledevents_cancelled", FormatString: "Cancelled scheduled event #%d"})
+	panelLogKeyRanNow    = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "scheduledevents_ran_now", FormatString: "Ran scheduled event #%d early"})
+)
+
+type Plugin struct{}
+
+func (p *Plugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{
+		Name:     "Scheduled Events Inspector",
+		SysName:  "scheduled_events_inspector",
+		Category: common.PluginCategoryCore,
+	}
+}
+
+func RegisterPlugin() {
+	common.RegisterPlugin(&Plugin{})
+}
+
+var _ web.Plugin = (*Plugin)(nil)
+
+func (p *Plugin) InitWeb() {
+	web.LoadHTMLTemplate("../../common/scheduledevents2/assets/scheduledevents.html", "templates/plugins/scheduledevents.html")
+	web.AddSidebarItem(web.SidebarCategoryCore, &web.SidebarItem{
+		Name: "Scheduled events",
+		URL:  "scheduledevents",
+		Icon: "fas fa-clock",
+	})
+
+	mux := goji.SubMux()
+	web.CPMux.Handle(pat.New("/scheduledevents/*"), mux)
+	web.CPMux.Handle(pat.New("/scheduledevents"), mux)
+
+	mainGetHandler := web.ControllerHandler(p.HandleEvents, "cp_scheduled_events")
+
+	mux.Handle(pat.Get("/"), mainGetHandler)
+	mux.Handle(pat.Get(""), mainGetHandler)
+
+	mux.Handle(pat.Post("/:event/cancel"), web.ControllerPostHandler(p.HandleCancel, mainGetHandler, nil))
+	mux.Handle(pat.Post("/:event/run"), web.ControllerPostHandler(p.HandleRunNow, mainGetHandler, nil))
+}
+
+func (p *Plugin) HandleEvents(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	events, err := scheduledevents2.GetPendingEvents(r.Context(), ag.ID, scheduledevents2.MaxListedEvents)
+	if err != nil {
+		return templateData, err
+	}
+
+	data := make(map[int64]string, len(events))
+	for _, v := range events {
+		data[v.ID] = scheduledevents2.EventDataString(v)
+	}
+
+	templateData["Events"] = events
+	templateData["EventData"] = data
+	templateData["MaxEvents"] = scheduledevents2.MaxListedEvents
+
+	return templateData, nil
+}
+
+func (p *Plugin) HandleCancel(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	id, _ := strconv.ParseInt(pat.Param(r, "event"), 10, 64)
+	found, err := scheduledevents2.CancelEvent(r.Context(), ag.ID, id)
+	if err != nil {
+		return templateData, err
+	}
+
+	if !found {
+		return templateData.AddAlerts(web.ErrorAlert("Event not found, it may already have run")), nil
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyCancelled, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: id}))
+
+	return templateData.AddAlerts(web.SucessAlert("Cancelled the event")), nil
+}
+
+func (p *Plugin) HandleRunNow(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	id, _ := strconv.ParseInt(pat.Param(r, "event"), 10, 64)
+	found, err := scheduledevents2.RunEventNow(r.Context(), ag.ID, id)
+	if err != nil {
+		return templateData, err
+	}
+
+	if !found {
+		return templateData.AddAlerts(web.ErrorAlert("Event not found, it may already have run")), nil
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRanNow, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: id}))
+
+	return templateData.AddAlerts(web.SucessAlert("The event will run within a couple of seconds")), nil
+}
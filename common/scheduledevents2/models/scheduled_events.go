@@ -34,6 +34,7 @@ type ScheduledEvent struct {
 	Data         types.JSON  `boil:"data" json:"data" toml:"data" yaml:"data"`
 	Processed    bool        `boil:"processed" json:"processed" toml:"processed" yaml:"processed"`
 	Error        null.String `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+	Retries      int         `boil:"retries" json:"retries" toml:"retries" yaml:"retries"`
 
 	R *scheduledEventR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L scheduledEventL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -49,6 +50,7 @@ var ScheduledEventColumns = struct {
 	Data         string
 	Processed    string
 	Error        string
+	Retries      string
 }{
 	ID:           "id",
 	CreatedAt:    "created_at",
@@ -59,6 +61,7 @@ var ScheduledEventColumns = struct {
 	Data:         "data",
 	Processed:    "processed",
 	Error:        "error",
+	Retries:      "retries",
 }
 
 // Generated where
@@ -155,6 +158,15 @@ func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 var ScheduledEventWhere = struct {
 	ID           whereHelperint64
 	CreatedAt    whereHelpertime_Time
@@ -165,6 +177,7 @@ var ScheduledEventWhere = struct {
 	Data         whereHelpertypes_JSON
 	Processed    whereHelperbool
 	Error        whereHelpernull_String
+	Retries      whereHelperint
 }{
 	ID:           whereHelperint64{field: "\"scheduled_events\".\"id\""},
 	CreatedAt:    whereHelpertime_Time{field: "\"scheduled_events\".\"created_at\""},
@@ -175,6 +188,7 @@ var ScheduledEventWhere = struct {
 	Data:         whereHelpertypes_JSON{field: "\"scheduled_events\".\"data\""},
 	Processed:    whereHelperbool{field: "\"scheduled_events\".\"processed\""},
 	Error:        whereHelpernull_String{field: "\"scheduled_events\".\"error\""},
+	Retries:      whereHelperint{field: "\"scheduled_events\".\"retries\""},
 }
 
 // ScheduledEventRels is where relationship names are stored.
@@ -194,9 +208,9 @@ func (*scheduledEventR) NewStruct() *scheduledEventR {
 type scheduledEventL struct{}
 
 var (
-	scheduledEventAllColumns            = []string{"id", "created_at", "triggers_at", "retry_on_error", "guild_id", "event_name", "data", "processed", "error"}
+	scheduledEventAllColumns            = []string{"id", "created_at", "triggers_at", "retry_on_error", "guild_id", "event_name", "data", "processed", "error", "retries"}
 	scheduledEventColumnsWithoutDefault = []string{"created_at", "triggers_at", "retry_on_error", "guild_id", "event_name", "data", "processed", "error"}
-	scheduledEventColumnsWithDefault    = []string{"id"}
+	scheduledEventColumnsWithDefault    = []string{"id", "retries"}
 	scheduledEventPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -334,6 +334,9 @@ func (se *ScheduledEvents) processItem(id int64, guildID int64) {
 			l.WithError(err).Error("handler returned an error")
 		}
 
+		// so it shows up when inspecting the event
+		recordAttempt(item.ID, retry, err)
+
 		if retry {
 			l.WithError(err).Warn("retrying handling event")
 			time.Sleep(retryDelay)
@@ -19,5 +19,9 @@ CREATE TABLE IF NOT EXISTS scheduled_events (
 CREATE INDEX IF NOT EXISTS scheduled_events_triggers_at_idx ON scheduled_events(triggers_at);
 `, `
 ALTER TABLE scheduled_events ADD COLUMN IF NOT EXISTS  error TEXT
+`, `
+ALTER TABLE scheduled_events ADD COLUMN IF NOT EXISTS retries INT NOT NULL DEFAULT 0;
+`, `
+CREATE INDEX IF NOT EXISTS scheduled_events_guild_id_idx ON scheduled_events(guild_id) WHERE processed=false;
 `,
 }
@@ -130,10 +130,22 @@ type Context struct {
 
 	IsPremium bool
 
+	// if not nil, only the context functions in this list will be available
+	AllowedContextFuncs []string
+
 	RegexCache map[string]*regexp.Regexp
 
 	CurrentFrame *contextFrame
 
+	// Interaction is set when triggered by a interaction that was acknowledged with a deferred response,
+	// the response is then sent as a followup to the interaction instead of a normal message
+	Interaction *discordgo.Interaction
+
+	// EphemeralResponse makes the response to the interaction only visible to the member that triggered it
+	EphemeralResponse bool
+
+	interactionResponded bool
+
 	contextFuncsAdded bool
 }
 
@@ -183,6 +195,14 @@ func (c *Context) setupContextFuncs() {
 		f(c)
 	}
 
+	if c.AllowedContextFuncs != nil {
+		for k := range c.ContextFuncs {
+			if !common.ContainsStringSlice(c.AllowedContextFuncs, k) {
+				delete(c.ContextFuncs, k)
+			}
+		}
+	}
+
 	c.contextFuncsAdded = true
 }
 
@@ -349,6 +369,11 @@ func (c *Context) MessageSend(content string) *discordgo.MessageSend {
 
 // SendResponse sends the response and handles reactions and the like
 func (c *Context) SendResponse(content string) (*discordgo.Message, error) {
+	if c.Interaction != nil && !c.interactionResponded && !c.CurrentFrame.SendResponseInDM {
+		c.interactionResponded = true
+		return c.sendInteractionResponse(content)
+	}
+
 	channelID := int64(0)
 
 	if !c.CurrentFrame.SendResponseInDM {
@@ -403,6 +428,58 @@ func (c *Context) SendResponse(content string) (*discordgo.Message, error) {
 	return m, nil
 }
 
+// sendInteractionResponse sends the response as the followup to the deferred interaction response
+func (c *Context) sendInteractionResponse(content string) (*discordgo.Message, error) {
+	if c.CurrentFrame.DelResponse && c.CurrentFrame.DelResponseDelay < 1 {
+		content = ""
+	}
+
+	empty := strings.TrimSpace(content) == "" && len(c.CurrentFrame.EmebdsToSend) < 1
+	if empty || c.EphemeralResponse {
+		// the first followup replaces the deferred response and keeps it public, so it has to go for ephemeral responses
+		err := common.BotSession.DeleteInteractionResponse(common.BotApplication.ID, c.Interaction.Token)
+		if err != nil || empty {
+			return nil, err
+		}
+	}
+
+	msgSend := c.MessageSend(content)
+	params := &discordgo.WebhookParams{
+		Content:         content,
+		Embeds:          c.CurrentFrame.EmebdsToSend,
+		AllowedMentions: &msgSend.AllowedMentions,
+	}
+
+	if c.EphemeralResponse {
+		params.Flags = 64
+	}
+
+	m, err := common.BotSession.CreateFollowupMessage(common.BotApplication.ID, c.Interaction.Token, params)
+	if err != nil {
+		logger.WithError(err).Error("Failed sending interaction response")
+		return nil, err
+	}
+
+	if c.EphemeralResponse {
+		// can't delete or react to those
+		return m, nil
+	}
+
+	if c.CurrentFrame.DelResponse {
+		MaybeScheduledDeleteMessage(c.GS.ID, m.ChannelID, m.ID, c.CurrentFrame.DelResponseDelay)
+	}
+
+	if len(c.CurrentFrame.AddResponseReactionNames) > 0 {
+		go func(frame *contextFrame) {
+			for _, v := range frame.AddResponseReactionNames {
+				common.BotSession.MessageReactionAdd(m.ChannelID, m.ID, v)
+			}
+		}(c.CurrentFrame)
+	}
+
+	return m, nil
+}
+
 // IncreaseCheckCallCounter Returns true if key is above the limit
 func (c *Context) IncreaseCheckCallCounter(key string, limit int) bool {
 	current, ok := c.Counters[key]
@@ -503,6 +580,7 @@ func baseContextFuncs(c *Context) {
 	c.addContextFunc("targetHasRoleName", c.tmplTargetHasRoleName)
 
 	c.addContextFunc("deleteResponse", c.tmplDelResponse)
+	c.addContextFunc("ephemeralResponse", c.tmplEphemeralResponse)
 	c.addContextFunc("deleteTrigger", c.tmplDelTrigger)
 	c.addContextFunc("deleteMessage", c.tmplDelMessage)
 	c.addContextFunc("deleteMessageReaction", c.tmplDelMessageReaction)
@@ -873,6 +873,12 @@ func (c *Context) tmplDelResponse(args ...interface{}) string {
 	return ""
 }
 
+// tmplEphemeralResponse makes the response to a slash command only visible to the member that used it, does nothing otherwise
+func (c *Context) tmplEphemeralResponse() string {
+	c.EphemeralResponse = true
+	return ""
+}
+
 func (c *Context) tmplDelTrigger(args ...interface{}) string {
 	if c.Msg != nil {
 		return c.tmplDelMessage(c.Msg.ChannelID, c.Msg.ID, args...)
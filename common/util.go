@@ -358,10 +358,25 @@ type LoggedExecutedCommand struct {
 	// If command returned any error this will be no-empty
 	Error string
 
+	// Either "message" or "slashcommand"
+	TriggerType string
+	// One of the ExecutedCommandStatus constants
+	Status int `gorm:"not null;default:0"`
+	// The commands.CanExecuteType the command was denied for, if the status is ExecutedCommandStatusDenied
+	DeniedReason int `gorm:"not null;default:0"`
+
 	TimeStamp    time.Time
 	ResponseTime int64
 }
 
+// The outcome of a logged command
+const (
+	ExecutedCommandStatusSuccess = iota
+	ExecutedCommandStatusError
+	// The member was not allowed to run the command, or it was on cooldown
+	ExecutedCommandStatusDenied
+)
+
 func (l LoggedExecutedCommand) TableName() string {
 	return "executed_commands"
 }
@@ -66,6 +66,13 @@ func (p *Plugin) AddCommands() {
 func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, bot.ConcurrentEventHandler(HandleMessageCreate), eventsystem.EventMessageCreate)
 	eventsystem.AddHandlerAsyncLastLegacy(p, bot.ConcurrentEventHandler(handleMessageReactions), eventsystem.EventMessageReactionAdd, eventsystem.EventMessageReactionRemove)
+	eventsystem.AddHandlerAsyncLastLegacy(p, handleMemberJoinLeave, eventsystem.EventGuildMemberAdd, eventsystem.EventGuildMemberRemove)
+	// deleted messages are kept in the state, flagged as deleted
+	eventsystem.AddHandlerAsyncLastLegacy(p, handleMessageDelete, eventsystem.EventMessageDelete)
+
+	// these need the state from before the event to provide the previous values
+	eventsystem.AddHandlerFirstLegacy(p, handleMemberUpdate, eventsystem.EventGuildMemberUpdate)
+	eventsystem.AddHandlerFirstLegacy(p, handleMessageUpdate, eventsystem.EventMessageUpdate)
 
 	pubsub.AddHandler("custom_commands_run_now", handleCustomCommandsRunNow, models.CustomCommand{})
 	scheduledevents2.RegisterHandler("cc_next_run", NextRunScheduledEvent{}, handleNextRunScheduledEVent)
@@ -708,8 +715,13 @@ func ExecuteCustomCommand(cmd *models.CustomCommand, tmplCtx *templates.Context)
 	lockHandle := CCExecLock.Lock(lockKey, time.Minute, time.Minute*10)
 	if lockHandle == -1 {
 		f.Warn("Exceeded max lock attempts for cc")
-		if cmd.ShowErrors {
-			common.BotSession.ChannelMessageSend(tmplCtx.CurrentFrame.CS.ID, fmt.Sprintf("Gave up trying to execute custom command #%d after 1 minute because there is already one or more instances of it being executed.", cmd.LocalID))
+		errMsg := fmt.Sprintf("Gave up trying to execute custom command #%d after 1 minute because there is already one or more instances of it being executed.", cmd.LocalID)
+		if tmplCtx.Interaction != nil {
+			// otherwise the deferred response is left showing the bot as thinking
+			tmplCtx.EphemeralResponse = true
+			tmplCtx.SendResponse(errMsg)
+		} else if cmd.ShowErrors {
+			common.BotSession.ChannelMessageSend(tmplCtx.CurrentFrame.CS.ID, errMsg)
 		}
 		updatePostCommandRan(cmd, errors.New("Gave up trying to execute, already an existing instance executing"))
 		return nil
@@ -1012,7 +1024,7 @@ func BotCachedGetCommandsWithMessageTriggers(guildID int64, ctx context.Context)
 		var err error
 
 		common.LogLongCallTime(time.Second, true, "Took longer than a second to fetch custom commands from db", logrus.Fields{"guild": guildID}, func() {
-			cmds, err = models.CustomCommands(qm.Where("guild_id = ? AND trigger_type IN (0,1,2,3,4,6)", guildID), qm.OrderBy("local_id desc"), qm.Load("Group")).AllG(ctx)
+			cmds, err = models.CustomCommands(qm.Where("guild_id = ? AND trigger_type IN (0,1,2,3,4,6,9,11,12,13,14,15,16)", guildID), qm.OrderBy("local_id desc"), qm.Load("Group")).AllG(ctx)
 		})
 
 		return cmds, err
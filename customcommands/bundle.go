@@ -0,0 +1,461 @@
+package customcommands
+
+import (
+	"encoding/json"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/dstate/v3"
+	"github.com/jonas747/yagpdb/customcommands/models"
+)
+
+const (
+	// BundleVersion is the version of the bundle format written by exports, bump it if the format changes in incompatible ways
+	BundleVersion = 1
+
+	// max size of an uploaded bundle, a full premium server is around 2.5MB of responses
+	MaxBundleSize = 4 << 20
+)
+
+// Bundle is a set of custom commands and their groups that can be exported from one server and imported into another
+type Bundle struct {
+	Version  int              `json:"version"`
+	Groups   []*BundleGroup   `json:"groups"`
+	Commands []*BundleCommand `json:"commands"`
+}
+
+// BundleRef refers to a channel or role, the name is used to find the matching one when importing into another server
+type BundleRef struct {
+	ID   int64  `json:"id,string"`
+	Name string `json:"name,omitempty"`
+}
+
+type BundleGroup struct {
+	Name              string      `json:"name"`
+	IgnoreRoles       []BundleRef `json:"ignore_roles,omitempty"`
+	IgnoreChannels    []BundleRef `json:"ignore_channels,omitempty"`
+	WhitelistRoles    []BundleRef `json:"whitelist_roles,omitempty"`
+	WhitelistChannels []BundleRef `json:"whitelist_channels,omitempty"`
+}
+
+type BundleCommand struct {
+	// Name of the group in the bundle, or of an existing group on the server it's imported into
+	Group string `json:"group,omitempty"`
+
+	// Same as the trigger types in the edit form, intervals are always in minutes
+	TriggerType   string   `json:"trigger_type"`
+	Trigger       string   `json:"trigger"`
+	CaseSensitive bool     `json:"case_sensitive"`
+	Responses     []string `json:"responses"`
+
+	Channels              []BundleRef `json:"channels,omitempty"`
+	ChannelsWhitelistMode bool        `json:"channels_whitelist_mode"`
+	Roles                 []BundleRef `json:"roles,omitempty"`
+	RolesWhitelistMode    bool        `json:"roles_whitelist_mode"`
+	ContextChannel        *BundleRef  `json:"context_channel,omitempty"`
+
+	TimeTriggerInterval       int     `json:"time_trigger_interval,omitempty"`
+	TimeTriggerExcludingDays  []int64 `json:"time_trigger_excluding_days,omitempty"`
+	TimeTriggerExcludingHours []int64 `json:"time_trigger_excluding_hours,omitempty"`
+
+	ReactionTriggerMode int `json:"reaction_trigger_mode,omitempty"`
+
+	SlashDescription string        `json:"slash_description,omitempty"`
+	SlashOptions     []SlashOption `json:"slash_options,omitempty"`
+	SlashEphemeral   bool          `json:"slash_ephemeral,omitempty"`
+
+	ShowErrors bool `json:"show_errors"`
+	Disabled   bool `json:"disabled"`
+}
+
+// ExportBundle creates a bundle of the provided groups and commands, gs is used to look up the channel and role names
+func ExportBundle(gs *dstate.GuildSet, groups []*models.CustomCommandGroup, ccs []*models.CustomCommand) *Bundle {
+	channelNames := make(map[int64]string, len(gs.Channels))
+	for _, v := range gs.Channels {
+		channelNames[v.ID] = v.Name
+	}
+
+	roleNames := make(map[int64]string, len(gs.Roles))
+	for _, v := range gs.Roles {
+		roleNames[v.ID] = v.Name
+	}
+
+	bundle := &Bundle{
+		Version:  BundleVersion,
+		Groups:   make([]*BundleGroup, 0, len(groups)),
+		Commands: make([]*BundleCommand, 0, len(ccs)),
+	}
+
+	groupNames := make(map[int64]string, len(groups))
+	for _, v := range groups {
+		groupNames[v.ID] = v.Name
+		bundle.Groups = append(bundle.Groups, &BundleGroup{
+			Name:              v.Name,
+			IgnoreRoles:       exportRefs(v.IgnoreRoles, roleNames),
+			IgnoreChannels:    exportRefs(v.IgnoreChannels, channelNames),
+			WhitelistRoles:    exportRefs(v.WhitelistRoles, roleNames),
+			WhitelistChannels: exportRefs(v.WhitelistChannels, channelNames),
+		})
+	}
+
+	for _, v := range ccs {
+		cmd := &BundleCommand{
+			TriggerType:   triggerTypeToForm(CommandTriggerType(v.TriggerType)),
+			Trigger:       v.TextTrigger,
+			CaseSensitive: v.TextTriggerCaseSensitive,
+			Responses:     v.Responses,
+
+			Channels:              exportRefs(v.Channels, channelNames),
+			ChannelsWhitelistMode: v.ChannelsWhitelistMode,
+			Roles:                 exportRefs(v.Roles, roleNames),
+			RolesWhitelistMode:    v.RolesWhitelistMode,
+
+			TimeTriggerInterval:       v.TimeTriggerInterval,
+			TimeTriggerExcludingDays:  v.TimeTriggerExcludingDays,
+			TimeTriggerExcludingHours: v.TimeTriggerExcludingHours,
+
+			ReactionTriggerMode: int(v.ReactionTriggerMode),
+
+			SlashDescription: v.SlashDescription,
+			SlashEphemeral:   v.SlashEphemeral,
+
+			ShowErrors: v.ShowErrors,
+			Disabled:   v.Disabled,
+		}
+
+		if v.GroupID.Valid {
+			cmd.Group = groupNames[v.GroupID.Int64]
+		}
+
+		if v.ContextChannel != 0 {
+			cmd.ContextChannel = &BundleRef{ID: v.ContextChannel, Name: channelNames[v.ContextChannel]}
+		}
+
+		if len(v.SlashOptions) > 0 {
+			// the options were validated when they were saved, so this shouldn't fail
+			json.Unmarshal(v.SlashOptions, &cmd.SlashOptions)
+		}
+
+		bundle.Commands = append(bundle.Commands, cmd)
+	}
+
+	return bundle
+}
+
+func exportRefs(ids []int64, names map[int64]string) []BundleRef {
+	if len(ids) < 1 {
+		return nil
+	}
+
+	result := make([]BundleRef, 0, len(ids))
+	for _, v := range ids {
+		result = append(result, BundleRef{ID: v, Name: names[v]})
+	}
+
+	return result
+}
+
+// DecodeBundle decodes a bundle and makes sure it's a version we know how to import
+func DecodeBundle(data []byte) (*Bundle, error) {
+	var bundle *Bundle
+	err := json.Unmarshal(data, &bundle)
+	if err != nil {
+		return nil, errors.WrapIf(err, "invalid bundle")
+	}
+
+	if bundle == nil || bundle.Version < 1 {
+		return nil, errors.New("invalid bundle: missing version")
+	}
+
+	if bundle.Version > BundleVersion {
+		return nil, errors.New("the bundle was made with a newer version of the bot")
+	}
+
+	for _, v := range bundle.Commands {
+		if v == nil {
+			return nil, errors.New("invalid bundle: empty command")
+		}
+	}
+
+	for _, v := range bundle.Groups {
+		if v == nil {
+			return nil, errors.New("invalid bundle: empty group")
+		}
+	}
+
+	return bundle, nil
+}
+
+// bundleRemapper maps the channels and roles in a bundle to the ones on the server it's imported into
+type bundleRemapper struct {
+	channelIDs   map[int64]string
+	channelNames map[string]int64
+	roleIDs      map[int64]string
+	roleNames    map[string]int64
+
+	// names (or ids if the name is unknown) of the channels and roles that couldn't be found
+	MissingChannels []string
+	MissingRoles    []string
+}
+
+func newBundleRemapper(gs *dstate.GuildSet) *bundleRemapper {
+	m := &bundleRemapper{
+		channelIDs:   make(map[int64]string, len(gs.Channels)),
+		channelNames: make(map[string]int64, len(gs.Channels)),
+		roleIDs:      make(map[int64]string, len(gs.Roles)),
+		roleNames:    make(map[string]int64, len(gs.Roles)),
+	}
+
+	for _, v := range gs.Channels {
+		m.channelIDs[v.ID] = v.Name
+
+		// categories can share names with channels, and are never used in restrictions
+		lower := strings.ToLower(v.Name)
+		if _, ok := m.channelNames[lower]; !ok && v.Type != discordgo.ChannelTypeGuildCategory {
+			m.channelNames[lower] = v.ID
+		}
+	}
+
+	for _, v := range gs.Roles {
+		m.roleIDs[v.ID] = v.Name
+
+		lower := strings.ToLower(v.Name)
+		if _, ok := m.roleNames[lower]; !ok {
+			m.roleNames[lower] = v.ID
+		}
+	}
+
+	return m
+}
+
+// resolveRef returns the id on this server that ref refers to, or 0 if there's none.
+// The id is kept if it has the same name (imported into the same server) or the name matches nothing (renamed since),
+// otherwise the name decides.
+func resolveRef(ref BundleRef, ids map[int64]string, names map[string]int64) int64 {
+	if name, ok := ids[ref.ID]; ok && (ref.Name == "" || strings.EqualFold(name, ref.Name)) {
+		return ref.ID
+	}
+
+	if ref.Name != "" {
+		if id, ok := names[strings.ToLower(ref.Name)]; ok {
+			return id
+		}
+	}
+
+	if _, ok := ids[ref.ID]; ok {
+		return ref.ID
+	}
+
+	return 0
+}
+
+func refDisplayName(ref BundleRef) string {
+	if ref.Name != "" {
+		return ref.Name
+	}
+
+	return discordgo.StrID(ref.ID)
+}
+
+func (m *bundleRemapper) Channel(ref BundleRef) int64 {
+	id := resolveRef(ref, m.channelIDs, m.channelNames)
+	if id == 0 {
+		m.MissingChannels = appendMissing(m.MissingChannels, refDisplayName(ref))
+	}
+
+	return id
+}
+
+func (m *bundleRemapper) Role(ref BundleRef) int64 {
+	id := resolveRef(ref, m.roleIDs, m.roleNames)
+	if id == 0 {
+		m.MissingRoles = appendMissing(m.MissingRoles, refDisplayName(ref))
+	}
+
+	return id
+}
+
+func (m *bundleRemapper) Channels(refs []BundleRef) []int64 {
+	result := make([]int64, 0, len(refs))
+	for _, v := range refs {
+		if id := m.Channel(v); id != 0 {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+func (m *bundleRemapper) Roles(refs []BundleRef) []int64 {
+	result := make([]int64, 0, len(refs))
+	for _, v := range refs {
+		if id := m.Role(v); id != 0 {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// WhitelistChannels is like Channels, but fails if one of the channels can't be found instead of leaving it out,
+// as leaving all of them out would turn the whitelist into no restriction at all
+func (m *bundleRemapper) WhitelistChannels(refs []BundleRef) ([]int64, error) {
+	result := make([]int64, 0, len(refs))
+	for _, v := range refs {
+		id := m.Channel(v)
+		if id == 0 {
+			return nil, errors.Errorf("the whitelisted channel %q wasn't found on this server", refDisplayName(v))
+		}
+
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+// WhitelistRoles is like Roles, but fails if one of the roles can't be found instead of leaving it out,
+// as leaving all of them out would turn the whitelist into no restriction at all
+func (m *bundleRemapper) WhitelistRoles(refs []BundleRef) ([]int64, error) {
+	result := make([]int64, 0, len(refs))
+	for _, v := range refs {
+		id := m.Role(v)
+		if id == 0 {
+			return nil, errors.Errorf("the whitelisted role %q wasn't found on this server", refDisplayName(v))
+		}
+
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+func appendMissing(missing []string, name string) []string {
+	for _, v := range missing {
+		if v == name {
+			return missing
+		}
+	}
+
+	return append(missing, name)
+}
+
+// ToDBModel returns the group with the channels and roles remapped to the ones on the server it's imported into,
+// missing ignored channels and roles are left out while missing whitelisted ones are an error
+func (g *BundleGroup) ToDBModel(m *bundleRemapper) (*models.CustomCommandGroup, error) {
+	whitelistRoles, err := m.WhitelistRoles(g.WhitelistRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	whitelistChannels, err := m.WhitelistChannels(g.WhitelistChannels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CustomCommandGroup{
+		Name:              g.Name,
+		IgnoreRoles:       m.Roles(g.IgnoreRoles),
+		IgnoreChannels:    m.Channels(g.IgnoreChannels),
+		WhitelistRoles:    whitelistRoles,
+		WhitelistChannels: whitelistChannels,
+	}, nil
+}
+
+// ToForm converts the command into the same form struct that's used when editing custom commands, so that it can go
+// through the same validation, with the channels and roles remapped to the ones on the server it's imported into.
+// Like with groups, missing channels and roles are only an error when they're used as a whitelist
+func (c *BundleCommand) ToForm(m *bundleRemapper) (*CustomCommand, error) {
+	cc := &CustomCommand{
+		TriggerTypeForm: c.TriggerType,
+		Trigger:         c.Trigger,
+		Responses:       c.Responses,
+		CaseSensitive:   c.CaseSensitive,
+
+		TimeTriggerInterval:       c.TimeTriggerInterval,
+		TimeTriggerExcludingDays:  c.TimeTriggerExcludingDays,
+		TimeTriggerExcludingHours: c.TimeTriggerExcludingHours,
+
+		ReactionTriggerMode: c.ReactionTriggerMode,
+
+		SlashDescription: c.SlashDescription,
+		SlashEphemeral:   c.SlashEphemeral,
+
+		RequireChannels: c.ChannelsWhitelistMode,
+		RequireRoles:    c.RolesWhitelistMode,
+
+		ShowErrors: c.ShowErrors,
+	}
+
+	var err error
+	if c.ChannelsWhitelistMode {
+		cc.Channels, err = m.WhitelistChannels(c.Channels)
+	} else {
+		cc.Channels = m.Channels(c.Channels)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c.RolesWhitelistMode {
+		cc.Roles, err = m.WhitelistRoles(c.Roles)
+	} else {
+		cc.Roles = m.Roles(c.Roles)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ContextChannel != nil {
+		cc.ContextChannel = m.Channel(*c.ContextChannel)
+	}
+
+	// the form keeps the choices as a comma separated string, validation rebuilds them from it.
+	// copied so that the options of the bundle are left untouched
+	cc.SlashOptions = make([]SlashOption, len(c.SlashOptions))
+	for i, v := range c.SlashOptions {
+		v.ChoicesForm = strings.Join(v.Choices, ",")
+		cc.SlashOptions[i] = v
+	}
+
+	cc.TriggerType = triggerTypeFromForm(cc.TriggerTypeForm)
+	return cc, nil
+}
+
+// triggerTypeToForm is the reverse of triggerTypeFromForm
+func triggerTypeToForm(t CommandTriggerType) string {
+	switch t {
+	case CommandTriggerNone:
+		return "none"
+	case CommandTriggerStartsWith:
+		return "prefix"
+	case CommandTriggerRegex:
+		return "regex"
+	case CommandTriggerContains:
+		return "contains"
+	case CommandTriggerExact:
+		return "exact"
+	case CommandTriggerReaction:
+		return "reaction"
+	case CommandTriggerSlash:
+		return "slash"
+	case CommandTriggerMemberJoin:
+		return "member_join"
+	case CommandTriggerMemberLeave:
+		return "member_leave"
+	case CommandTriggerRoleAdded:
+		return "role_added"
+	case CommandTriggerRoleRemoved:
+		return "role_removed"
+	case CommandTriggerNicknameChange:
+		return "nickname_change"
+	case CommandTriggerMessageDelete:
+		return "message_delete"
+	case CommandTriggerMessageEdit:
+		return "message_edit"
+	case CommandTriggerInterval:
+		return "interval_minutes"
+	default:
+		return "command"
+	}
+}
@@ -0,0 +1,184 @@
+package customcommands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/dstate/v3"
+	"github.com/jonas747/yagpdb/customcommands/models"
+	"github.com/volatiletech/null"
+)
+
+func TestBundleRemapper(t *testing.T) {
+	gs := &dstate.GuildSet{
+		Channels: []dstate.ChannelState{
+			{ID: 10, Name: "general", Type: discordgo.ChannelTypeGuildCategory},
+			{ID: 11, Name: "general", Type: discordgo.ChannelTypeGuildText},
+			{ID: 12, Name: "logs", Type: discordgo.ChannelTypeGuildText},
+		},
+		Roles: []discordgo.Role{
+			{ID: 20, Name: "Mod"},
+		},
+	}
+
+	m := newBundleRemapper(gs)
+
+	channelTests := []struct {
+		ref  BundleRef
+		want int64
+	}{
+		{BundleRef{ID: 12, Name: "logs"}, 12},     // same server
+		{BundleRef{ID: 500, Name: "General"}, 11}, // other server, matched by name and not the category
+		{BundleRef{ID: 12, Name: "old-logs"}, 12}, // renamed since the export
+		{BundleRef{ID: 501, Name: "gone"}, 0},
+		{BundleRef{ID: 502}, 0},
+	}
+
+	for i, v := range channelTests {
+		if got := m.Channel(v.ref); got != v.want {
+			t.Errorf("channel %d: got %d, expected %d", i, got, v.want)
+		}
+	}
+
+	if got := m.Roles([]BundleRef{{ID: 600, Name: "mod"}, {ID: 601, Name: "Admin"}}); !reflect.DeepEqual(got, []int64{20}) {
+		t.Errorf("got roles %v, expected [20]", got)
+	}
+
+	if !reflect.DeepEqual(m.MissingChannels, []string{"gone", "502"}) {
+		t.Errorf("unexpected missing channels: %v", m.MissingChannels)
+	}
+
+	if !reflect.DeepEqual(m.MissingRoles, []string{"Admin"}) {
+		t.Errorf("unexpected missing roles: %v", m.MissingRoles)
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	gs := &dstate.GuildSet{
+		Channels: []dstate.ChannelState{
+			{ID: 1, Name: "general", Type: discordgo.ChannelTypeGuildText},
+		},
+		Roles: []discordgo.Role{
+			{ID: 2, Name: "Mod"},
+		},
+	}
+
+	groups := []*models.CustomCommandGroup{{ID: 5, Name: "Fun", WhitelistRoles: []int64{2}}}
+	ccs := []*models.CustomCommand{
+		{
+			GroupID:             null.Int64From(5),
+			TriggerType:         int(CommandTriggerInterval),
+			TimeTriggerInterval: 60,
+			ContextChannel:      1,
+			Channels:            []int64{1},
+			Responses:           []string{"hi"},
+			SlashOptions:        []byte("[]"),
+		},
+		{
+			TriggerType:  int(CommandTriggerSlash),
+			TextTrigger:  "roll",
+			Responses:    []string{"{{randInt 6}}"},
+			SlashOptions: []byte(`[{"name":"size","type":"choices","choices":["small","big"]}]`),
+		},
+	}
+
+	bundle := ExportBundle(gs, groups, ccs)
+	if len(bundle.Commands) != 2 || bundle.Commands[0].Group != "Fun" || bundle.Commands[0].TriggerType != "interval_minutes" {
+		t.Fatalf("unexpected export: %+v", bundle.Commands[0])
+	}
+
+	m := newBundleRemapper(gs)
+	group, err := bundle.Groups[0].ToDBModel(m)
+	if err != nil {
+		t.Fatalf("failed converting group: %v", err)
+	}
+
+	if !reflect.DeepEqual([]int64(group.WhitelistRoles), []int64{2}) {
+		t.Errorf("unexpected group roles: %v", group.WhitelistRoles)
+	}
+
+	intervalForm, err := bundle.Commands[0].ToForm(m)
+	if err != nil {
+		t.Fatalf("failed converting interval command: %v", err)
+	}
+
+	interval := intervalForm.ToDBModel()
+	if interval.TriggerType != int(CommandTriggerInterval) || interval.TimeTriggerInterval != 60 || interval.ContextChannel != 1 {
+		t.Errorf("unexpected interval command: %+v", interval)
+	}
+
+	slash, err := bundle.Commands[1].ToForm(m)
+	if err != nil {
+		t.Fatalf("failed converting slash command: %v", err)
+	}
+
+	if slash.TriggerType != CommandTriggerSlash || len(slash.SlashOptions) != 1 || slash.SlashOptions[0].ChoicesForm != "small,big" {
+		t.Errorf("unexpected slash command: %+v", slash)
+	}
+
+	if bundle.Commands[1].SlashOptions[0].ChoicesForm != "" {
+		t.Error("converting to a form modified the bundle")
+	}
+}
+
+func TestBundleMissingWhitelist(t *testing.T) {
+	gs := &dstate.GuildSet{
+		Channels: []dstate.ChannelState{
+			{ID: 1, Name: "general", Type: discordgo.ChannelTypeGuildText},
+		},
+		Roles: []discordgo.Role{
+			{ID: 2, Name: "Mod"},
+		},
+	}
+
+	m := newBundleRemapper(gs)
+
+	// an empty whitelist means no restriction, so missing whitelisted roles and channels can't just be left out
+	if _, err := (&BundleGroup{Name: "Staff", WhitelistRoles: []BundleRef{{ID: 50, Name: "Staff"}}}).ToDBModel(m); err == nil {
+		t.Error("expected an error for a group with a missing whitelisted role")
+	}
+
+	if _, err := (&BundleCommand{TriggerType: "command", ChannelsWhitelistMode: true, Channels: []BundleRef{{ID: 51, Name: "staff-chat"}}}).ToForm(m); err == nil {
+		t.Error("expected an error for a command with a missing whitelisted channel")
+	}
+
+	// missing ignored ones are left out
+	group, err := (&BundleGroup{Name: "Fun", IgnoreRoles: []BundleRef{{ID: 50, Name: "Muted"}}, WhitelistChannels: []BundleRef{{ID: 1, Name: "general"}}}).ToDBModel(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(group.IgnoreRoles) != 0 || !reflect.DeepEqual([]int64(group.WhitelistChannels), []int64{1}) {
+		t.Errorf("unexpected group: %+v", group)
+	}
+
+	form, err := (&BundleCommand{TriggerType: "command", Roles: []BundleRef{{ID: 52, Name: "Muted"}}}).ToForm(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(form.Roles) != 0 {
+		t.Errorf("unexpected roles: %v", form.Roles)
+	}
+}
+
+func TestDecodeBundle(t *testing.T) {
+	tests := []struct {
+		raw   string
+		valid bool
+	}{
+		{`{"version":1,"commands":[{"trigger_type":"command","trigger":"a","responses":["b"]}]}`, true},
+		{`{"commands":[]}`, false},
+		{`{"version":99}`, false},
+		{`{"version":1,"commands":[null]}`, false},
+		{`not json`, false},
+	}
+
+	for i, v := range tests {
+		_, err := DecodeBundle([]byte(v.raw))
+		if (err == nil) != v.valid {
+			t.Errorf("%d: got err %v, expected valid: %v", i, err, v.valid)
+		}
+	}
+}
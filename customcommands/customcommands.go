@@ -64,6 +64,15 @@ const (
 	CommandTriggerReaction   CommandTriggerType = 6
 
 	CommandTriggerInterval CommandTriggerType = 5
+	CommandTriggerSlash    CommandTriggerType = 7
+
+	CommandTriggerMemberJoin     CommandTriggerType = 9
+	CommandTriggerMemberLeave    CommandTriggerType = 11
+	CommandTriggerRoleAdded      CommandTriggerType = 12
+	CommandTriggerRoleRemoved    CommandTriggerType = 13
+	CommandTriggerNicknameChange CommandTriggerType = 14
+	CommandTriggerMessageDelete  CommandTriggerType = 15
+	CommandTriggerMessageEdit    CommandTriggerType = 16
 )
 
 var (
@@ -75,6 +84,14 @@ var (
 		CommandTriggerExact,
 		CommandTriggerInterval,
 		CommandTriggerReaction,
+		CommandTriggerSlash,
+		CommandTriggerMemberJoin,
+		CommandTriggerMemberLeave,
+		CommandTriggerRoleAdded,
+		CommandTriggerRoleRemoved,
+		CommandTriggerNicknameChange,
+		CommandTriggerMessageDelete,
+		CommandTriggerMessageEdit,
 	}
 
 	triggerStrings = map[CommandTriggerType]string{
@@ -85,6 +102,15 @@ var (
 		CommandTriggerExact:      "Exact",
 		CommandTriggerInterval:   "Interval",
 		CommandTriggerReaction:   "Reaction",
+		CommandTriggerSlash:      "Slash",
+
+		CommandTriggerMemberJoin:     "MemberJoin",
+		CommandTriggerMemberLeave:    "MemberLeave",
+		CommandTriggerRoleAdded:      "RoleAdded",
+		CommandTriggerRoleRemoved:    "RoleRemoved",
+		CommandTriggerNicknameChange: "NicknameChange",
+		CommandTriggerMessageDelete:  "MessageDelete",
+		CommandTriggerMessageEdit:    "MessageEdit",
 	}
 )
 
@@ -116,6 +142,10 @@ type CustomCommand struct {
 
 	ReactionTriggerMode int `schema:"reaction_trigger_mode"`
 
+	SlashDescription string        `schema:"slash_description" valid:",100"`
+	SlashOptions     []SlashOption `schema:"slash_options"`
+	SlashEphemeral   bool          `schema:"slash_ephemeral"`
+
 	// If set, then the following channels are required, otherwise they are ignored
 	RequireChannels bool    `json:"require_channels" schema:"require_channels"`
 	Channels        []int64 `json:"channels" schema:"channels"`
@@ -165,6 +195,15 @@ func (cc *CustomCommand) Validate(tmpl web.TemplateData) (ok bool) {
 		return false
 	}
 
+	if t := triggerTypeFromForm(cc.TriggerTypeForm); isEventTrigger(t) && t != CommandTriggerMessageDelete && t != CommandTriggerMessageEdit && cc.ContextChannel == 0 {
+		tmpl.AddAlerts(web.ErrorAlert("Member event triggers need a channel to run in"))
+		return false
+	}
+
+	if cc.TriggerTypeForm == "slash" {
+		return cc.validateSlashTrigger(tmpl)
+	}
+
 	return true
 }
 
@@ -186,6 +225,9 @@ func (cc *CustomCommand) ToDBModel() *models.CustomCommand {
 
 		ReactionTriggerMode: int16(cc.ReactionTriggerMode),
 
+		SlashDescription: cc.SlashDescription,
+		SlashEphemeral:   cc.SlashEphemeral,
+
 		Responses: cc.Responses,
 
 		ShowErrors: cc.ShowErrors,
@@ -203,6 +245,11 @@ func (cc *CustomCommand) ToDBModel() *models.CustomCommand {
 		pqCommand.GroupID = null.Int64From(cc.GroupID)
 	}
 
+	pqCommand.SlashOptions, _ = json.Marshal(cc.SlashOptions)
+	if cc.SlashOptions == nil {
+		pqCommand.SlashOptions = []byte("[]")
+	}
+
 	if cc.TriggerTypeForm == "interval_hours" {
 		pqCommand.TimeTriggerInterval *= 60
 	}
@@ -383,7 +430,8 @@ func MaxCommandsForContext(ctx context.Context) int {
 var _ featureflags.PluginWithFeatureFlags = (*Plugin)(nil)
 
 const (
-	featureFlagHasCommands = "custom_commands_has_commands"
+	featureFlagHasCommands      = "custom_commands_has_commands"
+	featureFlagHasEventTriggers = "custom_commands_has_event_triggers"
 )
 
 func (p *Plugin) UpdateFeatureFlags(guildID int64) ([]string, error) {
@@ -398,11 +446,21 @@ func (p *Plugin) UpdateFeatureFlags(guildID int64) ([]string, error) {
 		flags = append(flags, featureFlagHasCommands)
 	}
 
+	eventCount, err := models.CustomCommands(qm.Where("guild_id = ? AND trigger_type IN (9,11,12,13,14,15,16)", guildID)).CountG(context.Background())
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	if eventCount > 0 {
+		flags = append(flags, featureFlagHasEventTriggers)
+	}
+
 	return flags, nil
 }
 
 func (p *Plugin) AllFeatureFlags() []string {
 	return []string{
-		featureFlagHasCommands, // set if this server has any custom commands at all
+		featureFlagHasCommands,      // set if this server has any custom commands at all
+		featureFlagHasEventTriggers, // set if this server has any member or message event triggered custom commands
 	}
 }
@@ -0,0 +1,336 @@
+package customcommands
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/dstate/v3"
+	"github.com/jonas747/yagpdb/bot"
+	"github.com/jonas747/yagpdb/bot/eventsystem"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/multiratelimit"
+	"github.com/jonas747/yagpdb/common/templates"
+	"github.com/jonas747/yagpdb/customcommands/models"
+	"github.com/jonas747/yagpdb/premium"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// EventCCRunLimit limits how often a single event triggered custom command can run,
+// as they can easily end up triggering each other (e.g one giving a role when another one is removed)
+var EventCCRunLimit = multiratelimit.NewMultiRatelimiter(0.5, 10)
+
+// ccEvent is a member or message event that can trigger custom commands
+type ccEvent struct {
+	Type CommandTriggerType
+	GS   *dstate.GuildSet
+	MS   *dstate.MemberState
+
+	// the channel the message was in, only set for message events
+	ChannelID int64
+	Msg       *discordgo.Message
+
+	// additional template data
+	Data map[string]interface{}
+}
+
+func isEventTrigger(t CommandTriggerType) bool {
+	switch t {
+	case CommandTriggerMemberJoin, CommandTriggerMemberLeave, CommandTriggerRoleAdded, CommandTriggerRoleRemoved,
+		CommandTriggerNicknameChange, CommandTriggerMessageDelete, CommandTriggerMessageEdit:
+		return true
+	}
+
+	return false
+}
+
+func handleMemberJoinLeave(evt *eventsystem.EventData) {
+	if !evt.HasFeatureFlag(featureFlagHasEventTriggers) {
+		return
+	}
+
+	switch e := evt.EvtInterface.(type) {
+	case *discordgo.GuildMemberAdd:
+		ms := dstate.MemberStateFromMember(e.Member)
+		ms.GuildID = e.GuildID
+
+		runEventCustomCommands(evt.Context(), &ccEvent{
+			Type: CommandTriggerMemberJoin,
+			GS:   evt.GS,
+			MS:   ms,
+		})
+	case *discordgo.GuildMemberRemove:
+		ms := dstate.MemberStateFromMember(e.Member)
+		ms.GuildID = e.GuildID
+
+		runEventCustomCommands(evt.Context(), &ccEvent{
+			Type: CommandTriggerMemberLeave,
+			GS:   evt.GS,
+			MS:   ms,
+		})
+	}
+}
+
+// handleMemberUpdate runs before the state is updated, so the old member is still available
+func handleMemberUpdate(evt *eventsystem.EventData) {
+	if !evt.HasFeatureFlag(featureFlagHasEventTriggers) {
+		return
+	}
+
+	mu := evt.GuildMemberUpdate()
+	if mu.User == nil {
+		return
+	}
+
+	oldMS := bot.State.GetMember(mu.GuildID, mu.User.ID)
+	if oldMS == nil || oldMS.Member == nil {
+		// we don't know what changed
+		return
+	}
+
+	ms := dstate.MemberStateFromMember(mu.Member)
+	ms.GuildID = mu.GuildID
+
+	addedIDs, removedIDs := diffRoles(oldMS.Member.Roles, mu.Roles)
+	added := resolveRoles(evt.GS, addedIDs)
+	removed := resolveRoles(evt.GS, removedIDs)
+
+	oldNick := oldMS.Member.Nick
+	gs := evt.GS
+
+	go func() {
+		roleData := map[string]interface{}{
+			"OldMember":    oldMS,
+			"AddedRoles":   added,
+			"RemovedRoles": removed,
+		}
+
+		if len(added) > 0 {
+			runEventCustomCommands(context.Background(), &ccEvent{Type: CommandTriggerRoleAdded, GS: gs, MS: ms, Data: roleData})
+		}
+
+		if len(removed) > 0 {
+			runEventCustomCommands(context.Background(), &ccEvent{Type: CommandTriggerRoleRemoved, GS: gs, MS: ms, Data: roleData})
+		}
+
+		if oldNick != mu.Nick {
+			runEventCustomCommands(context.Background(), &ccEvent{
+				Type: CommandTriggerNicknameChange,
+				GS:   gs,
+				MS:   ms,
+				Data: map[string]interface{}{
+					"OldMember":   oldMS,
+					"OldNickname": oldNick,
+					"NewNickname": mu.Nick,
+				},
+			})
+		}
+	}()
+}
+
+// handleMessageUpdate runs before the state is updated, so the message from before the edit is still available
+func handleMessageUpdate(evt *eventsystem.EventData) {
+	if !evt.HasFeatureFlag(featureFlagHasEventTriggers) {
+		return
+	}
+
+	mu := evt.MessageUpdate()
+	if mu.GuildID == 0 || mu.EditedTimestamp == "" || !bot.IsNormalUserMessage(mu.Message) {
+		// embed edits and the like
+		return
+	}
+
+	if mu.Member == nil {
+		// discord doesn't always include the member in updates
+		return
+	}
+
+	oldMsg := findStateMessage(mu.GuildID, mu.ChannelID, mu.ID)
+	if oldMsg != nil && oldMsg.Content == mu.Content {
+		return
+	}
+
+	member := *mu.Member
+	member.User = mu.Author
+	member.GuildID = mu.GuildID
+
+	ms := dstate.MemberStateFromMember(&member)
+	gs := evt.GS
+
+	go runEventCustomCommands(context.Background(), &ccEvent{
+		Type:      CommandTriggerMessageEdit,
+		GS:        gs,
+		MS:        ms,
+		ChannelID: mu.ChannelID,
+		Msg:       mu.Message,
+		Data: map[string]interface{}{
+			"Message":    mu.Message,
+			"OldMessage": oldMsg,
+		},
+	})
+}
+
+// handleMessageDelete runs after the state is updated, deleted messages are only known if they're still in the state
+// (flagged as deleted). Bulk deletes don't run message_delete triggers, those are mostly moderators or bots cleaning up
+// channels and would run the custom commands once per message.
+func handleMessageDelete(evt *eventsystem.EventData) {
+	if !evt.HasFeatureFlag(featureFlagHasEventTriggers) {
+		return
+	}
+
+	md := evt.MessageDelete()
+	if md.GuildID == 0 {
+		return
+	}
+
+	oldMsg := findStateMessage(md.GuildID, md.ChannelID, md.ID)
+	if oldMsg == nil || oldMsg.Author.ID == common.BotUser.ID {
+		return
+	}
+
+	gs := evt.GS
+
+	go func() {
+		ms, err := bot.GetMember(gs.ID, oldMsg.Author.ID)
+		if err != nil {
+			// most likely left the server
+			return
+		}
+
+		runEventCustomCommands(context.Background(), &ccEvent{
+			Type:      CommandTriggerMessageDelete,
+			GS:        gs,
+			MS:        ms,
+			ChannelID: oldMsg.ChannelID,
+			Data: map[string]interface{}{
+				"OldMessage": oldMsg,
+			},
+		})
+	}()
+}
+
+func runEventCustomCommands(ctx context.Context, event *ccEvent) {
+	cmds, err := BotCachedGetCommandsWithMessageTriggers(event.GS.ID, ctx)
+	if err != nil {
+		logger.WithError(err).WithField("guild", event.GS.ID).Error("failed retrieving event ccs")
+		return
+	}
+
+	var matched []*models.CustomCommand
+	for _, cmd := range cmds {
+		if cmd.TriggerType != int(event.Type) {
+			continue
+		}
+
+		// the roles of members that left are not known
+		if event.Type != CommandTriggerMemberLeave && !CmdRunsForUser(cmd, event.MS) {
+			continue
+		}
+
+		if event.ChannelID != 0 && !CmdRunsInChannel(cmd, event.ChannelID) {
+			continue
+		}
+
+		matched = append(matched, cmd)
+	}
+
+	if len(matched) < 1 {
+		return
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].LocalID < matched[j].LocalID
+	})
+
+	limit := CCMessageExecLimitNormal
+	if isPremium, _ := premium.IsGuildPremiumCached(event.GS.ID); isPremium {
+		limit = CCMessageExecLimitPremium
+	}
+
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	metricsExecutedCommands.With(prometheus.Labels{"trigger": "event"}).Inc()
+
+	for _, cmd := range matched {
+		cs := event.GS.GetChannel(cmd.ContextChannel)
+		if cs == nil && event.ChannelID != 0 {
+			// message events run in the channel of the message if no channel was set
+			cs = event.GS.GetChannel(event.ChannelID)
+		}
+
+		if cs == nil {
+			continue
+		}
+
+		if !EventCCRunLimit.AllowN(CCExecKey{GuildID: cmd.GuildID, CCID: cmd.LocalID}, time.Now(), 1) {
+			logger.WithField("guild", cmd.GuildID).WithField("cc_id", cmd.LocalID).Warn("event cc ratelimited")
+			continue
+		}
+
+		err = ExecuteCustomCommandFromEvent(cmd, event, cs)
+		if err != nil {
+			logger.WithField("guild", cmd.GuildID).WithField("cc_id", cmd.LocalID).WithError(err).Error("Error executing custom command")
+		}
+	}
+}
+
+func ExecuteCustomCommandFromEvent(cmd *models.CustomCommand, event *ccEvent, cs *dstate.ChannelState) error {
+	tmplCtx := templates.NewContext(event.GS, cs, event.MS)
+	if event.Msg != nil {
+		tmplCtx.Msg = event.Msg
+	}
+
+	for k, v := range event.Data {
+		tmplCtx.Data[k] = v
+	}
+
+	return ExecuteCustomCommand(cmd, tmplCtx)
+}
+
+// diffRoles returns the roles that are in newRoles but not in oldRoles, and the ones in oldRoles but not in newRoles
+func diffRoles(oldRoles, newRoles []int64) (added, removed []int64) {
+	for _, v := range newRoles {
+		if !common.ContainsInt64Slice(oldRoles, v) {
+			added = append(added, v)
+		}
+	}
+
+	for _, v := range oldRoles {
+		if !common.ContainsInt64Slice(newRoles, v) {
+			removed = append(removed, v)
+		}
+	}
+
+	return
+}
+
+func resolveRoles(gs *dstate.GuildSet, ids []int64) []*discordgo.Role {
+	roles := make([]*discordgo.Role, 0, len(ids))
+	for _, v := range ids {
+		if role := gs.GetRole(v); role != nil {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
+
+// findStateMessage returns the message from the state, or nil if it's not in there
+func findStateMessage(guildID, channelID, messageID int64) *dstate.MessageState {
+	// only the message itself is between these, and the state stops looking once it's past it
+	messages := bot.State.GetMessages(guildID, channelID, &dstate.MessagesQuery{
+		Before:         messageID + 1,
+		After:          messageID - 1,
+		Limit:          1,
+		IncludeDeleted: true,
+	})
+
+	if len(messages) < 1 || messages[0] == nil || messages[0].ID != messageID {
+		return nil
+	}
+
+	return messages[0]
+}
@@ -0,0 +1,28 @@
+package customcommands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRoles(t *testing.T) {
+	tests := []struct {
+		oldRoles []int64
+		newRoles []int64
+
+		added   []int64
+		removed []int64
+	}{
+		{[]int64{1, 2}, []int64{1, 2}, nil, nil},
+		{nil, []int64{1}, []int64{1}, nil},
+		{[]int64{1, 2}, []int64{2}, nil, []int64{1}},
+		{[]int64{1, 2}, []int64{2, 3, 4}, []int64{3, 4}, []int64{1}},
+	}
+
+	for i, v := range tests {
+		added, removed := diffRoles(v.oldRoles, v.newRoles)
+		if !reflect.DeepEqual(added, v.added) || !reflect.DeepEqual(removed, v.removed) {
+			t.Errorf("%d: got added %v removed %v, expected added %v removed %v", i, added, removed, v.added, v.removed)
+		}
+	}
+}
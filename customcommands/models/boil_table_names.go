@@ -4,11 +4,13 @@
 package models
 
 var TableNames = struct {
-	CustomCommandGroups   string
-	CustomCommands        string
-	TemplatesUserDatabase string
+	CustomCommandGroups    string
+	CustomCommandRevisions string
+	CustomCommands         string
+	TemplatesUserDatabase  string
 }{
-	CustomCommandGroups:   "custom_command_groups",
-	CustomCommands:        "custom_commands",
-	TemplatesUserDatabase: "templates_user_database",
+	CustomCommandGroups:    "custom_command_groups",
+	CustomCommandRevisions: "custom_command_revisions",
+	CustomCommands:         "custom_commands",
+	TemplatesUserDatabase:  "templates_user_database",
 }
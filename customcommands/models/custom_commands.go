@@ -48,6 +48,9 @@ type CustomCommand struct {
 	LastErrorTime             null.Time         `boil:"last_error_time" json:"last_error_time,omitempty" toml:"last_error_time" yaml:"last_error_time,omitempty"`
 	RunCount                  int               `boil:"run_count" json:"run_count" toml:"run_count" yaml:"run_count"`
 	ShowErrors                bool              `boil:"show_errors" json:"show_errors" toml:"show_errors" yaml:"show_errors"`
+	SlashDescription          string            `boil:"slash_description" json:"slash_description" toml:"slash_description" yaml:"slash_description"`
+	SlashOptions              types.JSON        `boil:"slash_options" json:"slash_options" toml:"slash_options" yaml:"slash_options"`
+	SlashEphemeral            bool              `boil:"slash_ephemeral" json:"slash_ephemeral" toml:"slash_ephemeral" yaml:"slash_ephemeral"`
 
 	R *customCommandR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L customCommandL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -77,6 +80,9 @@ var CustomCommandColumns = struct {
 	LastErrorTime             string
 	RunCount                  string
 	ShowErrors                string
+	SlashDescription          string
+	SlashOptions              string
+	SlashEphemeral            string
 }{
 	LocalID:                   "local_id",
 	GuildID:                   "guild_id",
@@ -101,6 +107,9 @@ var CustomCommandColumns = struct {
 	LastErrorTime:             "last_error_time",
 	RunCount:                  "run_count",
 	ShowErrors:                "show_errors",
+	SlashDescription:          "slash_description",
+	SlashOptions:              "slash_options",
+	SlashEphemeral:            "slash_ephemeral",
 }
 
 // Generated where
@@ -169,36 +178,36 @@ func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-type whereHelpertypes_StringArray struct{ field string }
+type whereHelperint16 struct{ field string }
+
+func (w whereHelperint16) EQ(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint16) NEQ(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint16) LT(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint16) LTE(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint16) GT(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint16) GTE(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+type whereHelpertypes_JSON struct{ field string }
 
-func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
+func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.EQ, x)
 }
-func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
+func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.NEQ, x)
 }
-func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
+func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
+func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
+func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
+func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-type whereHelperint16 struct{ field string }
-
-func (w whereHelperint16) EQ(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint16) NEQ(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint16) LT(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint16) LTE(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint16) GT(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint16) GTE(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
 var CustomCommandWhere = struct {
 	LocalID                   whereHelperint64
 	GuildID                   whereHelperint64
@@ -223,6 +232,9 @@ var CustomCommandWhere = struct {
 	LastErrorTime             whereHelpernull_Time
 	RunCount                  whereHelperint
 	ShowErrors                whereHelperbool
+	SlashDescription          whereHelperstring
+	SlashOptions              whereHelpertypes_JSON
+	SlashEphemeral            whereHelperbool
 }{
 	LocalID:                   whereHelperint64{field: "\"custom_commands\".\"local_id\""},
 	GuildID:                   whereHelperint64{field: "\"custom_commands\".\"guild_id\""},
@@ -247,6 +259,9 @@ var CustomCommandWhere = struct {
 	LastErrorTime:             whereHelpernull_Time{field: "\"custom_commands\".\"last_error_time\""},
 	RunCount:                  whereHelperint{field: "\"custom_commands\".\"run_count\""},
 	ShowErrors:                whereHelperbool{field: "\"custom_commands\".\"show_errors\""},
+	SlashDescription:          whereHelperstring{field: "\"custom_commands\".\"slash_description\""},
+	SlashOptions:              whereHelpertypes_JSON{field: "\"custom_commands\".\"slash_options\""},
+	SlashEphemeral:            whereHelperbool{field: "\"custom_commands\".\"slash_ephemeral\""},
 }
 
 // CustomCommandRels is where relationship names are stored.
@@ -270,9 +285,9 @@ func (*customCommandR) NewStruct() *customCommandR {
 type customCommandL struct{}
 
 var (
-	customCommandAllColumns            = []string{"local_id", "guild_id", "group_id", "trigger_type", "text_trigger", "text_trigger_case_sensitive", "time_trigger_interval", "time_trigger_excluding_days", "time_trigger_excluding_hours", "last_run", "next_run", "responses", "channels", "channels_whitelist_mode", "roles", "roles_whitelist_mode", "context_channel", "reaction_trigger_mode", "disabled", "last_error", "last_error_time", "run_count", "show_errors"}
+	customCommandAllColumns            = []string{"local_id", "guild_id", "group_id", "trigger_type", "text_trigger", "text_trigger_case_sensitive", "time_trigger_interval", "time_trigger_excluding_days", "time_trigger_excluding_hours", "last_run", "next_run", "responses", "channels", "channels_whitelist_mode", "roles", "roles_whitelist_mode", "context_channel", "reaction_trigger_mode", "disabled", "last_error", "last_error_time", "run_count", "show_errors", "slash_description", "slash_options", "slash_ephemeral"}
 	customCommandColumnsWithoutDefault = []string{"local_id", "guild_id", "group_id", "trigger_type", "text_trigger", "text_trigger_case_sensitive", "time_trigger_interval", "time_trigger_excluding_days", "time_trigger_excluding_hours", "last_run", "next_run", "responses", "channels", "channels_whitelist_mode", "roles", "roles_whitelist_mode", "last_error_time"}
-	customCommandColumnsWithDefault    = []string{"context_channel", "reaction_trigger_mode", "disabled", "last_error", "run_count", "show_errors"}
+	customCommandColumnsWithDefault    = []string{"context_channel", "reaction_trigger_mode", "disabled", "last_error", "run_count", "show_errors", "slash_description", "slash_options", "slash_ephemeral"}
 	customCommandPrimaryKeyColumns     = []string{"guild_id", "local_id"}
 )
 
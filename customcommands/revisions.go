@@ -0,0 +1,268 @@
+package customcommands
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/customcommands/models"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+const (
+	// MaxRevisionsPerCommand is the number of revisions of the responses kept for each custom command
+	MaxRevisionsPerCommand = 25
+
+	// revisions are diffed with a simple LCS table, above this many cells it falls back to showing everything as changed
+	maxDiffCells = 4000000
+
+	// number of unchanged lines shown around changes
+	diffContextLines = 3
+)
+
+// SaveCommandRevision stores responses as a new revision of the custom command if they changed.
+// previous is what the responses were before the edit, if they don't match the latest stored revision
+// (there was no history yet for example) they're stored as well, with an unknown editor.
+func SaveCommandRevision(ctx context.Context, guildID, localID int64, previous, responses []string, editedBy int64) error {
+	latest, err := models.CustomCommandRevisions(
+		qm.Where("guild_id = ? AND local_id = ?", guildID, localID),
+		qm.OrderBy("id desc")).OneG(ctx)
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return errors.WithStackIf(err)
+	}
+
+	if previous != nil && (latest == nil || !responsesEqual(latest.Responses, previous)) {
+		// there was no history yet, or they were changed without storing a revision
+		latest, err = insertRevision(ctx, guildID, localID, previous, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	if latest != nil && responsesEqual(latest.Responses, responses) {
+		return nil
+	}
+
+	_, err = insertRevision(ctx, guildID, localID, responses, editedBy)
+	if err != nil {
+		return err
+	}
+
+	const q = `DELETE FROM custom_command_revisions WHERE guild_id = $1 AND local_id = $2 AND id NOT IN (
+	SELECT id FROM custom_command_revisions WHERE guild_id = $1 AND local_id = $2 ORDER BY id DESC LIMIT $3
+)`
+	_, err = common.PQ.Exec(q, guildID, localID, MaxRevisionsPerCommand)
+	return errors.WithStackIf(err)
+}
+
+func insertRevision(ctx context.Context, guildID, localID int64, responses []string, editedBy int64) (*models.CustomCommandRevision, error) {
+	rev := &models.CustomCommandRevision{
+		GuildID:   guildID,
+		LocalID:   localID,
+		Responses: responses,
+		EditedBy:  editedBy,
+	}
+
+	err := rev.InsertG(ctx, boil.Infer())
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	return rev, nil
+}
+
+func responsesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	DiffLineSame    = " "
+	DiffLineAdded   = "+"
+	DiffLineRemoved = "-"
+	DiffLineSkipped = "~" // a run of unchanged lines that is not shown, Text is the number of lines
+)
+
+type DiffLine struct {
+	Kind string
+	Text string
+}
+
+// ResponseDiff is the diff of a single response between two revisions
+type ResponseDiff struct {
+	Index   int // 1 based
+	Changed bool
+	Lines   []DiffLine
+}
+
+// DiffResponses diffs the responses of 2 revisions, response by response
+func DiffResponses(oldResponses, newResponses []string) []*ResponseDiff {
+	n := len(oldResponses)
+	if len(newResponses) > n {
+		n = len(newResponses)
+	}
+
+	result := make([]*ResponseDiff, 0, n)
+	for i := 0; i < n; i++ {
+		var oldLines, newLines []string
+		if i < len(oldResponses) {
+			oldLines = strings.Split(oldResponses[i], "\n")
+		}
+		if i < len(newResponses) {
+			newLines = strings.Split(newResponses[i], "\n")
+		}
+
+		lines := diffLines(oldLines, newLines)
+		changed := false
+		for _, v := range lines {
+			if v.Kind != DiffLineSame {
+				changed = true
+				break
+			}
+		}
+
+		result = append(result, &ResponseDiff{
+			Index:   i + 1,
+			Changed: changed,
+			Lines:   collapseUnchanged(lines, diffContextLines),
+		})
+	}
+
+	return result
+}
+
+// diffLines returns a line diff of a and b using their longest common subsequence
+func diffLines(a, b []string) []DiffLine {
+	// the common start and end don't need to go through the table, most edits are small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]DiffLine, 0, len(a)+len(b))
+	for _, v := range a[:prefix] {
+		result = append(result, DiffLine{Kind: DiffLineSame, Text: v})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	if len(midA)*len(midB) > maxDiffCells {
+		for _, v := range midA {
+			result = append(result, DiffLine{Kind: DiffLineRemoved, Text: v})
+		}
+		for _, v := range midB {
+			result = append(result, DiffLine{Kind: DiffLineAdded, Text: v})
+		}
+	} else {
+		result = append(result, lcsDiff(midA, midB)...)
+	}
+
+	for _, v := range a[len(a)-suffix:] {
+		result = append(result, DiffLine{Kind: DiffLineSame, Text: v})
+	}
+
+	return result
+}
+
+func lcsDiff(a, b []string) []DiffLine {
+	// table[i][j] is the length of the lcs of a[i:] and b[j:]
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] >= table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	result := make([]DiffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, DiffLine{Kind: DiffLineSame, Text: a[i]})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			result = append(result, DiffLine{Kind: DiffLineRemoved, Text: a[i]})
+			i++
+		default:
+			result = append(result, DiffLine{Kind: DiffLineAdded, Text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		result = append(result, DiffLine{Kind: DiffLineRemoved, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, DiffLine{Kind: DiffLineAdded, Text: b[j]})
+	}
+
+	return result
+}
+
+// collapseUnchanged replaces runs of unchanged lines further than contextLines away from a change with a single skipped line
+func collapseUnchanged(lines []DiffLine, contextLines int) []DiffLine {
+	keep := make([]bool, len(lines))
+	for i, v := range lines {
+		if v.Kind == DiffLineSame {
+			continue
+		}
+
+		for j := i - contextLines; j <= i+contextLines; j++ {
+			if j >= 0 && j < len(lines) {
+				keep[j] = true
+			}
+		}
+	}
+
+	result := make([]DiffLine, 0, len(lines))
+	skipped := 0
+	for i, v := range lines {
+		if keep[i] {
+			if skipped > 0 {
+				result = append(result, DiffLine{Kind: DiffLineSkipped, Text: strconv.Itoa(skipped)})
+				skipped = 0
+			}
+
+			result = append(result, v)
+			continue
+		}
+
+		skipped++
+	}
+
+	if skipped > 0 {
+		result = append(result, DiffLine{Kind: DiffLineSkipped, Text: strconv.Itoa(skipped)})
+	}
+
+	return result
+}
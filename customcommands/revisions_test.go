@@ -0,0 +1,62 @@
+package customcommands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want []DiffLine
+	}{
+		{
+			[]string{"a", "b", "c"},
+			[]string{"a", "b", "c"},
+			[]DiffLine{{" ", "a"}, {" ", "b"}, {" ", "c"}},
+		},
+		{
+			[]string{"a", "b", "c"},
+			[]string{"a", "x", "c"},
+			[]DiffLine{{" ", "a"}, {"-", "b"}, {"+", "x"}, {" ", "c"}},
+		},
+		{
+			nil,
+			[]string{"a"},
+			[]DiffLine{{"+", "a"}},
+		},
+		{
+			[]string{"a", "b", "c", "d"},
+			[]string{"b", "d", "e"},
+			[]DiffLine{{"-", "a"}, {" ", "b"}, {"-", "c"}, {" ", "d"}, {"+", "e"}},
+		},
+	}
+
+	for i, v := range tests {
+		got := diffLines(v.a, v.b)
+		if !reflect.DeepEqual(got, v.want) {
+			t.Errorf("%d: got %v, expected %v", i, got, v.want)
+		}
+	}
+}
+
+func TestCollapseUnchanged(t *testing.T) {
+	lines := []DiffLine{{" ", "1"}, {" ", "2"}, {" ", "3"}, {"+", "4"}, {" ", "5"}, {" ", "6"}}
+
+	got := collapseUnchanged(lines, 1)
+	want := []DiffLine{{"~", "2"}, {" ", "3"}, {"+", "4"}, {" ", "5"}, {"~", "1"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, expected %v", got, want)
+	}
+}
+
+func TestDiffResponses(t *testing.T) {
+	diff := DiffResponses([]string{"a\nb", "c"}, []string{"a\nb"})
+	if len(diff) != 2 {
+		t.Fatalf("got %d responses, expected 2", len(diff))
+	}
+
+	if diff[0].Changed || !diff[1].Changed {
+		t.Errorf("unexpected changed responses: %v, %v", diff[0].Changed, diff[1].Changed)
+	}
+}
@@ -59,6 +59,25 @@ ALTER TABLE custom_commands ADD COLUMN IF NOT EXISTS show_errors BOOLEAN NOT NUL
 `, `
 ALTER TABLE custom_commands ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT false;
 `, `
+ALTER TABLE custom_commands ADD COLUMN IF NOT EXISTS slash_description TEXT NOT NULL DEFAULT '';
+`, `
+ALTER TABLE custom_commands ADD COLUMN IF NOT EXISTS slash_options JSONB NOT NULL DEFAULT '[]';
+`, `
+ALTER TABLE custom_commands ADD COLUMN IF NOT EXISTS slash_ephemeral BOOLEAN NOT NULL DEFAULT false;
+`, `
+CREATE TABLE IF NOT EXISTS custom_command_revisions (
+	id BIGSERIAL PRIMARY KEY,
+
+	guild_id BIGINT NOT NULL,
+	local_id BIGINT NOT NULL,
+
+	responses TEXT[] NOT NULL,
+	edited_by BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+`, `
+CREATE INDEX IF NOT EXISTS custom_command_revisions_cmd_idx ON custom_command_revisions(guild_id, local_id);
+`, `
 CREATE TABLE IF NOT EXISTS templates_user_database (
 	id BIGSERIAL PRIMARY KEY,
 
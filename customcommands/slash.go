@@ -0,0 +1,376 @@
+package customcommands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/dstate/v3"
+	"github.com/jonas747/yagpdb/bot"
+	"github.com/jonas747/yagpdb/bot/eventsystem"
+	"github.com/jonas747/yagpdb/commands"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/featureflags"
+	"github.com/jonas747/yagpdb/common/templates"
+	"github.com/jonas747/yagpdb/customcommands/models"
+	"github.com/jonas747/yagpdb/web"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+const (
+	SlashOptionTypeString  = "string"
+	SlashOptionTypeInt     = "int"
+	SlashOptionTypeUser    = "user"
+	SlashOptionTypeChannel = "channel"
+	SlashOptionTypeRole    = "role"
+	SlashOptionTypeChoices = "choices"
+
+	MaxSlashOptions = 25
+	MaxSlashChoices = 25
+)
+
+var slashCommandNameRegex = regexp.MustCompile(`^[\w-]{1,32}$`)
+
+// SlashOption is a option of a custom command with a slash command trigger, stored as json in the slash_options column
+type SlashOption struct {
+	Name        string   `json:"name" schema:"name"`
+	Description string   `json:"description" schema:"description"`
+	Type        string   `json:"type" schema:"type"`
+	Required    bool     `json:"required" schema:"required"`
+	Choices     []string `json:"choices,omitempty" schema:"-"`
+
+	// comma separated choices from the form
+	ChoicesForm string `json:"-" schema:"choices"`
+}
+
+var _ commands.GuildSlashCommandsProvider = (*Plugin)(nil)
+
+// DecodeSlashOptions returns the options of the custom command, or none if it isn't triggered by a slash command
+func DecodeSlashOptions(cc *models.CustomCommand) ([]*SlashOption, error) {
+	if cc.TriggerType != int(CommandTriggerSlash) || len(cc.SlashOptions) < 1 {
+		return nil, nil
+	}
+
+	var opts []*SlashOption
+	err := json.Unmarshal(cc.SlashOptions, &opts)
+	return opts, err
+}
+
+// validateSlashTrigger validates the slash command name and options, and removes the empty option rows from the form
+func (cc *CustomCommand) validateSlashTrigger(tmpl web.TemplateData) bool {
+	cc.Trigger = strings.ToLower(strings.TrimSpace(cc.Trigger))
+	if !slashCommandNameRegex.MatchString(cc.Trigger) {
+		tmpl.AddAlerts(web.ErrorAlert("Slash command names can only contain letters, numbers, - and _, and be max 32 characters long"))
+		return false
+	}
+
+	if commands.IsBuiltinSlashCommandName(cc.Trigger) {
+		tmpl.AddAlerts(web.ErrorAlert("There's already a built in slash command named ", cc.Trigger))
+		return false
+	}
+
+	filtered := make([]SlashOption, 0, len(cc.SlashOptions))
+	seenOptional := false
+	for _, v := range cc.SlashOptions {
+		v.Name = strings.ToLower(strings.TrimSpace(v.Name))
+		if v.Name == "" {
+			continue
+		}
+
+		if !slashCommandNameRegex.MatchString(v.Name) {
+			tmpl.AddAlerts(web.ErrorAlert("Invalid option name ", v.Name, ": can only contain letters, numbers, - and _, and be max 32 characters long"))
+			return false
+		}
+
+		for _, f := range filtered {
+			if f.Name == v.Name {
+				tmpl.AddAlerts(web.ErrorAlert("Duplicate option name ", v.Name))
+				return false
+			}
+		}
+
+		if utf8.RuneCountInString(v.Description) > 100 {
+			tmpl.AddAlerts(web.ErrorAlert("Description of option ", v.Name, " is too long, max 100 characters"))
+			return false
+		}
+
+		switch v.Type {
+		case SlashOptionTypeString, SlashOptionTypeInt, SlashOptionTypeUser, SlashOptionTypeChannel, SlashOptionTypeRole:
+		case SlashOptionTypeChoices:
+			v.Choices = nil
+			for _, c := range strings.Split(v.ChoicesForm, ",") {
+				c = strings.TrimSpace(c)
+				if c != "" && !common.ContainsStringSlice(v.Choices, c) {
+					v.Choices = append(v.Choices, c)
+				}
+			}
+
+			if len(v.Choices) < 1 || len(v.Choices) > MaxSlashChoices {
+				tmpl.AddAlerts(web.ErrorAlert(fmt.Sprintf("Option %s needs between 1 and %d comma separated choices", v.Name, MaxSlashChoices)))
+				return false
+			}
+
+			for _, c := range v.Choices {
+				if utf8.RuneCountInString(c) > 100 {
+					tmpl.AddAlerts(web.ErrorAlert("Choice ", c, " is too long, max 100 characters"))
+					return false
+				}
+			}
+		default:
+			tmpl.AddAlerts(web.ErrorAlert("Unknown type of option ", v.Name))
+			return false
+		}
+
+		if v.Required && seenOptional {
+			tmpl.AddAlerts(web.ErrorAlert("Required options has to come before the optional ones"))
+			return false
+		}
+		seenOptional = seenOptional || !v.Required
+
+		filtered = append(filtered, v)
+	}
+
+	if len(filtered) > MaxSlashOptions {
+		tmpl.AddAlerts(web.ErrorAlert(fmt.Sprintf("Too many options, max %d", MaxSlashOptions)))
+		return false
+	}
+
+	cc.SlashOptions = filtered
+	return true
+}
+
+func slashCommandRequest(cc *models.CustomCommand) (*discordgo.CreateApplicationCommandRequest, error) {
+	opts, err := DecodeSlashOptions(cc)
+	if err != nil {
+		return nil, err
+	}
+
+	description := cc.SlashDescription
+	if description == "" {
+		description = fmt.Sprintf("Custom command #%d", cc.LocalID)
+	}
+
+	t := true
+	req := &discordgo.CreateApplicationCommandRequest{
+		Name:              cc.TextTrigger,
+		Description:       common.CutStringShort(description, 100),
+		DefaultPermission: &t,
+		Options:           make([]*discordgo.ApplicationCommandOption, 0, len(opts)),
+	}
+
+	for _, v := range opts {
+		opt := &discordgo.ApplicationCommandOption{
+			Name:        v.Name,
+			Description: v.Description,
+			Required:    v.Required,
+		}
+
+		if opt.Description == "" {
+			opt.Description = v.Name
+		}
+
+		switch v.Type {
+		case SlashOptionTypeInt:
+			opt.Kind = discordgo.CommandOptionTypeInteger
+		case SlashOptionTypeUser:
+			opt.Kind = discordgo.CommandOptionTypeUser
+		case SlashOptionTypeChannel:
+			opt.Kind = discordgo.CommandOptionTypeChannel
+		case SlashOptionTypeRole:
+			opt.Kind = discordgo.CommandOptionTypeRole
+		case SlashOptionTypeChoices:
+			opt.Kind = discordgo.CommandOptionTypeString
+			for _, c := range v.Choices {
+				opt.Choices = append(opt.Choices, &discordgo.ApplicationCommandOptionChoice{Name: c, Value: c})
+			}
+		default:
+			opt.Kind = discordgo.CommandOptionTypeString
+		}
+
+		req.Options = append(req.Options, opt)
+	}
+
+	return req, nil
+}
+
+// GuildSlashCommands implements commands.GuildSlashCommandsProvider
+func (p *Plugin) GuildSlashCommands(guildID int64) ([]*discordgo.CreateApplicationCommandRequest, error) {
+	ccs, err := models.CustomCommands(qm.Where("guild_id = ? AND trigger_type = ?", guildID, int(CommandTriggerSlash)), qm.OrderBy("local_id asc")).AllG(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*discordgo.CreateApplicationCommandRequest, 0, len(ccs))
+	for _, v := range ccs {
+		if v.TextTrigger == "" || commands.IsBuiltinSlashCommandName(v.TextTrigger) {
+			continue
+		}
+
+		req, err := slashCommandRequest(v)
+		if err != nil {
+			logger.WithError(err).WithField("guild", guildID).WithField("cc_id", v.LocalID).Error("failed decoding slash options")
+			continue
+		}
+
+		result = append(result, req)
+	}
+
+	return result, nil
+}
+
+// HandleGuildSlashCommand implements commands.GuildSlashCommandsProvider
+func (p *Plugin) HandleGuildSlashCommand(evt *eventsystem.EventData) (handled bool) {
+	interaction := evt.InteractionCreate()
+	if interaction.Member == nil || !featureflags.GuildHasFlagOrLogError(interaction.GuildID, featureFlagHasCommands) {
+		return false
+	}
+
+	cmd, err := models.CustomCommands(
+		qm.Where("guild_id = ? AND trigger_type = ? AND text_trigger = ?", interaction.GuildID, int(CommandTriggerSlash), interaction.DataCommand.Name),
+		qm.Load("Group")).OneG(evt.Context())
+	if err != nil {
+		if errors.Cause(err) != sql.ErrNoRows {
+			logger.WithError(err).WithField("guild", interaction.GuildID).Error("failed retrieving slash custom command")
+		}
+		return false
+	}
+
+	gs := bot.State.GetGuild(interaction.GuildID)
+	if gs == nil {
+		return true
+	}
+
+	cs := gs.GetChannel(interaction.ChannelID)
+	if cs == nil {
+		return true
+	}
+
+	ms := dstate.MemberStateFromMember(interaction.Member)
+	ms.GuildID = gs.ID
+
+	if !CmdRunsInChannel(cmd, cs.ID) || !CmdRunsForUser(cmd, ms) {
+		content := "You can't use this command here."
+		err = common.BotSession.CreateInteractionResponse(interaction.ID, interaction.Token, &discordgo.InteractionResponse{
+			Kind: discordgo.InteractionResponseTypeChannelMessageWithSource,
+			Data: &discordgo.InteractionApplicationCommandCallbackData{
+				Content: &content,
+				Flags:   64,
+			},
+		})
+		if err != nil {
+			logger.WithError(err).WithField("guild", gs.ID).Error("failed responding to slash custom command")
+		}
+		return true
+	}
+
+	// the response is sent as a followup when the template is done executing
+	resp := &discordgo.InteractionResponse{
+		Kind: discordgo.InteractionResponseTypeDeferredChannelMessageWithSource,
+	}
+	if cmd.SlashEphemeral {
+		resp.Data = &discordgo.InteractionApplicationCommandCallbackData{Flags: 64}
+	}
+
+	err = common.BotSession.CreateInteractionResponse(interaction.ID, interaction.Token, resp)
+	if err != nil {
+		logger.WithError(err).WithField("guild", gs.ID).Error("failed acknowledging slash custom command")
+		return true
+	}
+
+	metricsExecutedCommands.With(prometheus.Labels{"trigger": "slash"}).Inc()
+
+	err = ExecuteCustomCommandFromSlash(gs, cmd, ms, cs, &interaction.Interaction)
+	if err != nil {
+		logger.WithField("guild", gs.ID).WithField("cc_id", cmd.LocalID).WithError(err).Error("Error executing custom command")
+	}
+
+	return true
+}
+
+// ExecuteCustomCommandFromSlash runs the custom command with the slash command options as arguments,
+// .CmdArgs holds the typed values and .Args the raw ones used by parseArgs
+func ExecuteCustomCommandFromSlash(gs *dstate.GuildSet, cmd *models.CustomCommand, ms *dstate.MemberState, cs *dstate.ChannelState, interaction *discordgo.Interaction) error {
+	tmplCtx := templates.NewContext(gs, cs, ms)
+	tmplCtx.Interaction = interaction
+	tmplCtx.EphemeralResponse = cmd.SlashEphemeral
+
+	opts, err := DecodeSlashOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	given := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, v := range interaction.DataCommand.Options {
+		given[v.Name] = v
+	}
+
+	cmdArgs := make([]interface{}, 0, len(opts))
+	rawArgs := make([]string, 0, len(opts))
+	filledRawArgs := make([]string, 0, len(opts))
+	for _, v := range opts {
+		opt, ok := given[v.Name]
+		if !ok || opt.Value == nil {
+			// optional ones the member didn't fill in are nil, so the later ones keep their index
+			cmdArgs = append(cmdArgs, nil)
+			rawArgs = append(rawArgs, "")
+			continue
+		}
+
+		value, raw := slashOptionValue(gs, interaction.DataCommand, v, opt.Value)
+		cmdArgs = append(cmdArgs, value)
+		rawArgs = append(rawArgs, raw)
+		filledRawArgs = append(filledRawArgs, raw)
+	}
+
+	tmplCtx.Data["Cmd"] = "/" + cmd.TextTrigger
+	tmplCtx.Data["CmdArgs"] = cmdArgs
+	tmplCtx.Data["Args"] = append([]string{"/" + cmd.TextTrigger}, rawArgs...)
+	tmplCtx.Data["StrippedMsg"] = strings.Join(filledRawArgs, " ")
+
+	return ExecuteCustomCommand(cmd, tmplCtx)
+}
+
+// slashOptionValue returns the template value of a option, and it in the form a member would have typed it in
+func slashOptionValue(gs *dstate.GuildSet, cmdData *discordgo.ApplicationCommandInteractionData, def *SlashOption, value interface{}) (interface{}, string) {
+	switch def.Type {
+	case SlashOptionTypeInt:
+		// json numbers are decoded as float64
+		f, _ := value.(float64)
+		return int64(f), strconv.FormatInt(int64(f), 10)
+	case SlashOptionTypeUser, SlashOptionTypeChannel, SlashOptionTypeRole:
+		s, _ := value.(string)
+		id, _ := strconv.ParseInt(s, 10, 64)
+
+		switch def.Type {
+		case SlashOptionTypeUser:
+			if user, ok := cmdData.Resolved.Users[id]; ok {
+				return user, "<@" + s + ">"
+			}
+
+			return nil, "<@" + s + ">"
+		case SlashOptionTypeChannel:
+			if cs := gs.GetChannel(id); cs != nil {
+				return templates.CtxChannelFromCS(cs), "<#" + s + ">"
+			}
+
+			return nil, "<#" + s + ">"
+		default:
+			if role := gs.GetRole(id); role != nil {
+				return role, s
+			}
+
+			return nil, s
+		}
+	}
+
+	s := fmt.Sprint(value)
+	return s, s
+}
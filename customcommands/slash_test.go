@@ -0,0 +1,44 @@
+package customcommands
+
+import (
+	"testing"
+
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/customcommands/models"
+)
+
+func TestSlashCommandRequest(t *testing.T) {
+	cc := &models.CustomCommand{
+		LocalID:      5,
+		TriggerType:  int(CommandTriggerSlash),
+		TextTrigger:  "give",
+		SlashOptions: []byte(`[{"name":"who","type":"user","required":true},{"name":"what","description":"The thing","type":"choices","choices":["cookie","cake"]}]`),
+	}
+
+	req, err := slashCommandRequest(cc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Name != "give" || req.Description != "Custom command #5" {
+		t.Errorf("unexpected name or description: %q, %q", req.Name, req.Description)
+	}
+
+	if len(req.Options) != 2 {
+		t.Fatalf("got %d options, expected 2", len(req.Options))
+	}
+
+	who := req.Options[0]
+	if who.Kind != discordgo.CommandOptionTypeUser || !who.Required || who.Description != "who" {
+		t.Errorf("unexpected first option: %#v", who)
+	}
+
+	what := req.Options[1]
+	if what.Kind != discordgo.CommandOptionTypeString || what.Required || what.Description != "The thing" {
+		t.Errorf("unexpected second option: %#v", what)
+	}
+
+	if len(what.Choices) != 2 || what.Choices[1].Value != "cake" {
+		t.Errorf("unexpected choices: %#v", what.Choices)
+	}
+}
@@ -90,18 +90,27 @@ func tmplCArg(typ string, name string, opts ...interface{}) (*dcmd.ArgDef, error
 func tmplExpectArgs(ctx *templates.Context) interface{} {
 	return func(numRequired int, failedMessage string, args ...*dcmd.ArgDef) (*ParsedArgs, error) {
 		result := &ParsedArgs{}
-		if len(args) == 0 || ctx.Msg == nil || ctx.Data["StrippedMsg"] == nil {
+		if len(args) == 0 || (ctx.Msg == nil && ctx.Interaction == nil) || ctx.Data["StrippedMsg"] == nil {
 			return result, nil
 		}
 
 		result.defs = args
 
-		msg := ctx.Msg
-		stripped := ctx.Data["StrippedMsg"].(string)
-		split := dcmd.SplitArgs(stripped)
-
 		// create the dcmd data context used in the arg parsing
-		dcmdData, err := commands.CommandSystem.FillDataLegacyMessage(common.BotSession, msg)
+		var dcmdData *dcmd.Data
+		var split []*dcmd.RawArg
+		var err error
+		if ctx.Interaction != nil {
+			// slash command option values are in .Args the same way a member would have typed them in
+			for _, v := range ctx.Data["Args"].([]string)[1:] {
+				split = append(split, &dcmd.RawArg{Str: v})
+			}
+
+			dcmdData, err = commands.CommandSystem.FillDataInteraction(common.BotSession, ctx.Interaction)
+		} else {
+			split = dcmd.SplitArgs(ctx.Data["StrippedMsg"].(string))
+			dcmdData, err = commands.CommandSystem.FillDataLegacyMessage(common.BotSession, ctx.Msg)
+		}
 		if err != nil {
 			return result, errors.WithMessage(err, "tmplExpectArgs")
 		}
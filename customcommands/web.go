@@ -2,8 +2,12 @@ package customcommands
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,6 +18,8 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/bot/botrest"
+	"github.com/jonas747/yagpdb/commands"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/cplogs"
 	"github.com/jonas747/yagpdb/common/featureflags"
@@ -39,9 +45,11 @@ type GroupForm struct {
 }
 
 var (
-	panelLogKeyNewCommand     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_new_command", FormatString: "Created a new custom command: %d"})
-	panelLogKeyUpdatedCommand = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_updated_command", FormatString: "Updated custom command: %d"})
-	panelLogKeyRemovedCommand = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_removed_command", FormatString: "Removed custom command: %d"})
+	panelLogKeyNewCommand      = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_new_command", FormatString: "Created a new custom command: %d"})
+	panelLogKeyUpdatedCommand  = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_updated_command", FormatString: "Updated custom command: %d"})
+	panelLogKeyRemovedCommand  = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_removed_command", FormatString: "Removed custom command: %d"})
+	panelLogKeyRestoredCommand = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_restored_command", FormatString: "Restored custom command %d to an earlier revision"})
+	panelLogKeyImportedBundle  = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_imported_bundle", FormatString: "Imported %d custom commands from a bundle"})
 
 	panelLogKeyNewGroup     = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_new_group", FormatString: "Created a new custom command group: %s"})
 	panelLogKeyUpdatedGroup = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "customcommands_updated_group", FormatString: "Updated custom command group: %s"})
@@ -52,6 +60,7 @@ var (
 func (p *Plugin) InitWeb() {
 	web.LoadHTMLTemplate("../../customcommands/assets/customcommands.html", "templates/plugins/customcommands.html")
 	web.LoadHTMLTemplate("../../customcommands/assets/customcommands-editcmd.html", "templates/plugins/customcommands-editcmd.html")
+	web.LoadHTMLTemplate("../../customcommands/assets/customcommands-history.html", "templates/plugins/customcommands-history.html")
 	web.AddSidebarItem(web.SidebarCategoryCore, &web.SidebarItem{
 		Name: "Custom commands",
 		URL:  "customcommands",
@@ -61,6 +70,7 @@ func (p *Plugin) InitWeb() {
 	getHandler := web.ControllerHandler(handleCommands, "cp_custom_commands")
 	getCmdHandler := web.ControllerHandler(handleGetCommand, "cp_custom_commands_edit_cmd")
 	getGroupHandler := web.ControllerHandler(handleGetCommandsGroup, "cp_custom_commands")
+	getHistoryHandler := web.ControllerHandler(handleGetCommandHistory, "cp_custom_commands_cmd_history")
 
 	subMux := goji.SubMux()
 	web.CPMux.Handle(pat.New("/customcommands"), subMux)
@@ -84,6 +94,8 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Get("/"), getHandler)
 
 	subMux.Handle(pat.Get("/commands/:cmd/"), getCmdHandler)
+	subMux.Handle(pat.Get("/commands/:cmd/history"), getHistoryHandler)
+	subMux.Handle(pat.Get("/export"), http.HandlerFunc(handleExportBundle))
 
 	subMux.Handle(pat.Get("/groups/:group/"), web.ControllerHandler(handleGetCommandsGroup, "cp_custom_commands"))
 	subMux.Handle(pat.Get("/groups/:group"), web.ControllerHandler(handleGetCommandsGroup, "cp_custom_commands"))
@@ -93,6 +105,9 @@ func (p *Plugin) InitWeb() {
 	subMux.Handle(pat.Post("/commands/:cmd/update"), web.ControllerPostHandler(handleUpdateCommand, getCmdHandler, CustomCommand{}))
 	subMux.Handle(pat.Post("/commands/:cmd/delete"), web.ControllerPostHandler(handleDeleteCommand, getHandler, nil))
 	subMux.Handle(pat.Post("/commands/:cmd/run_now"), web.ControllerPostHandler(handleRunCommandNow, getCmdHandler, nil))
+	subMux.Handle(pat.Post("/commands/:cmd/history/:revision/restore"), web.ControllerPostHandler(handleRestoreCommandRevision, getHistoryHandler, nil))
+
+	subMux.Handle(pat.Post("/import"), web.ControllerPostHandler(handleImportBundle, getHandler, nil))
 
 	subMux.Handle(pat.Post("/creategroup"), web.ControllerPostHandler(handleNewGroup, getHandler, GroupForm{}))
 	subMux.Handle(pat.Post("/groups/:group/update"), web.ControllerPostHandler(handleUpdateGroup, getGroupHandler, GroupForm{}))
@@ -132,7 +147,13 @@ func handleGetCommand(w http.ResponseWriter, r *http.Request) (web.TemplateData,
 		return templateData, errors.WithStackIf(err)
 	}
 
+	slashOptions, err := DecodeSlashOptions(cc)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
 	templateData["CC"] = cc
+	templateData["CCSlashOptions"] = slashOptions
 	templateData["Commands"] = true
 
 	return serveGroupSelected(r, templateData, cc.GroupID.Int64, activeGuild.ID)
@@ -238,6 +259,11 @@ func handleNewCommand(w http.ResponseWriter, r *http.Request) (web.TemplateData,
 
 	featureflags.MarkGuildDirty(activeGuild.ID)
 
+	err = SaveCommandRevision(ctx, activeGuild.ID, localID, nil, dbModel.Responses, web.ContextUser(ctx).ID)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).WithField("guild", activeGuild.ID).Error("failed saving custom command revision")
+	}
+
 	http.Redirect(w, r, fmt.Sprintf("/manage/%d/customcommands/commands/%d/", activeGuild.ID, localID), http.StatusSeeOther)
 
 	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyNewCommand, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: dbModel.LocalID}))
@@ -284,11 +310,37 @@ func handleUpdateCommand(w http.ResponseWriter, r *http.Request) (web.TemplateDa
 		}
 	}
 
-	_, err := dbModel.UpdateG(ctx, boil.Blacklist("last_run", "next_run", "local_id", "guild_id", "last_error", "last_error_time", "run_count"))
+	if dbModel.TriggerType == int(CommandTriggerSlash) {
+		c, err := models.CustomCommands(qm.Where("guild_id = ? AND local_id != ? AND trigger_type = ? AND text_trigger = ?", activeGuild.ID, dbModel.LocalID, int(CommandTriggerSlash), dbModel.TextTrigger)).CountG(ctx)
+		if err != nil {
+			return templateData, err
+		}
+
+		if c > 0 {
+			return templateData.AddAlerts(web.ErrorAlert("There's already a custom command with the slash command name ", dbModel.TextTrigger)), nil
+		}
+
+		ok, err := checkSlashCommandLimit(ctx, activeGuild.ID, dbModel.LocalID, templateData)
+		if err != nil || !ok {
+			return templateData, err
+		}
+	}
+
+	previous, err := models.FindCustomCommandG(ctx, activeGuild.ID, dbModel.LocalID, "responses")
+	if err != nil {
+		return templateData, err
+	}
+
+	_, err = dbModel.UpdateG(ctx, boil.Blacklist("last_run", "next_run", "local_id", "guild_id", "last_error", "last_error_time", "run_count"))
 	if err != nil {
 		return templateData, nil
 	}
 
+	err = SaveCommandRevision(ctx, activeGuild.ID, dbModel.LocalID, previous.Responses, dbModel.Responses, web.ContextUser(ctx).ID)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).WithField("guild", activeGuild.ID).Error("failed saving custom command revision")
+	}
+
 	// create, update or remove the next run time and scheduled event
 	if dbModel.TriggerType == int(CommandTriggerInterval) {
 		// need the last run time
@@ -306,9 +358,13 @@ func handleUpdateCommand(w http.ResponseWriter, r *http.Request) (web.TemplateDa
 		web.CtxLogger(ctx).WithError(err).WithField("guild", dbModel.GuildID).Error("failed updating next custom command run time")
 	}
 
+	// the trigger type might have changed to or from an event trigger
+	featureflags.MarkGuildDirty(activeGuild.ID)
+
 	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedCommand, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: dbModel.LocalID}))
 
 	pubsub.EvictCacheSet(cachedCommandsMessage, activeGuild.ID)
+	commands.PubsubSendUpdateGuildSlashCommands(activeGuild.ID)
 	return templateData, err
 }
 
@@ -336,11 +392,19 @@ func handleDeleteCommand(w http.ResponseWriter, r *http.Request) (web.TemplateDa
 		return templateData, err
 	}
 
+	_, err = models.CustomCommandRevisions(qm.Where("guild_id = ? AND local_id = ?", activeGuild.ID, cmd.LocalID)).DeleteAll(ctx, common.PQ)
+	if err != nil {
+		return templateData, err
+	}
+
 	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRemovedCommand, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: cmd.LocalID}))
 
 	err = DelNextRunEvent(cmd.GuildID, cmd.LocalID)
 	featureflags.MarkGuildDirty(activeGuild.ID)
 	pubsub.EvictCacheSet(cachedCommandsMessage, activeGuild.ID)
+	if cmd.TriggerType == int(CommandTriggerSlash) {
+		commands.PubsubSendUpdateGuildSlashCommands(activeGuild.ID)
+	}
 	return templateData, err
 }
 
@@ -395,6 +459,380 @@ func handleRunCommandNow(w http.ResponseWriter, r *http.Request) (web.TemplateDa
 	return templateData, nil
 }
 
+// commandRevisionView is a revision along with what changed in it compared to the one before it
+type commandRevisionView struct {
+	*models.CustomCommandRevision
+
+	Diff    []*ResponseDiff
+	Current bool // the command currently has the responses of this revision
+	Oldest  bool // the oldest revision kept, so there's nothing to compare with
+}
+
+func handleGetCommandHistory(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	ccID, err := strconv.ParseInt(pat.Param(r, "cmd"), 10, 64)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	cc, err := models.CustomCommands(
+		models.CustomCommandWhere.GuildID.EQ(activeGuild.ID),
+		models.CustomCommandWhere.LocalID.EQ(ccID)).OneG(ctx)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	revisions, err := models.CustomCommandRevisions(
+		models.CustomCommandRevisionWhere.GuildID.EQ(activeGuild.ID),
+		models.CustomCommandRevisionWhere.LocalID.EQ(ccID),
+		qm.OrderBy("id desc")).AllG(ctx)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	// newest first, so the revision before each one is the next in the list
+	views := make([]*commandRevisionView, len(revisions))
+	for i, v := range revisions {
+		var before []string
+		if i+1 < len(revisions) {
+			before = revisions[i+1].Responses
+		}
+
+		views[i] = &commandRevisionView{
+			CustomCommandRevision: v,
+			Diff:                  DiffResponses(before, v.Responses),
+			Current:               responsesEqual(v.Responses, cc.Responses),
+			Oldest:                i == len(revisions)-1,
+		}
+	}
+
+	var editorIDs []int64
+	for _, v := range revisions {
+		if v.EditedBy != 0 && !common.ContainsInt64Slice(editorIDs, v.EditedBy) {
+			editorIDs = append(editorIDs, v.EditedBy)
+		}
+	}
+
+	editorNames := make(map[int64]string)
+	if len(editorIDs) > 0 {
+		members, err := botrest.GetMembers(activeGuild.ID, editorIDs...)
+		if err != nil {
+			web.CtxLogger(ctx).WithError(err).Error("failed fetching custom command revision editors")
+		}
+
+		for _, m := range members {
+			if m != nil && m.User != nil {
+				editorNames[m.User.ID] = m.User.Username + "#" + m.User.Discriminator
+			}
+		}
+	}
+
+	templateData["CC"] = cc
+	templateData["Revisions"] = views
+	templateData["EditorNames"] = editorNames
+
+	return serveGroupSelected(r, templateData, cc.GroupID.Int64, activeGuild.ID)
+}
+
+func handleRestoreCommandRevision(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	ccID, err := strconv.ParseInt(pat.Param(r, "cmd"), 10, 64)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	revisionID, err := strconv.ParseInt(pat.Param(r, "revision"), 10, 64)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	cc, err := models.FindCustomCommandG(ctx, activeGuild.ID, ccID)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	revision, err := models.CustomCommandRevisions(qm.Where("guild_id = ? AND local_id = ? AND id = ?", activeGuild.ID, ccID, revisionID)).OneG(ctx)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return templateData.AddAlerts(web.ErrorAlert("Unknown revision")), nil
+		}
+
+		return templateData, errors.WithStackIf(err)
+	}
+
+	previous := cc.Responses
+	cc.Responses = revision.Responses
+	_, err = cc.UpdateG(ctx, boil.Whitelist("responses"))
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	// the restore itself is a new revision, so it can be undone as well
+	err = SaveCommandRevision(ctx, activeGuild.ID, ccID, previous, cc.Responses, web.ContextUser(ctx).ID)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).WithField("guild", activeGuild.ID).Error("failed saving custom command revision")
+	}
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRestoredCommand, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: ccID}))
+
+	pubsub.EvictCacheSet(cachedCommandsMessage, activeGuild.ID)
+	return templateData.AddAlerts(web.SucessAlert("Restored the revision from ", revision.CreatedAt.UTC().Format("2006-01-02 15:04:05 MST"))), nil
+}
+
+// handleExportBundle serves all the custom commands and groups on the server as a bundle that can be imported elsewhere
+func handleExportBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	activeGuild, _ := web.GetBaseCPContextData(ctx)
+
+	groups, err := models.CustomCommandGroups(qm.Where("guild_id = ?", activeGuild.ID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving custom command groups for export")
+		http.Error(w, "failed retrieving custom commands", http.StatusInternalServerError)
+		return
+	}
+
+	ccs, err := models.CustomCommands(qm.Where("guild_id = ?", activeGuild.ID), qm.OrderBy("local_id asc")).AllG(ctx)
+	if err != nil {
+		web.CtxLogger(ctx).WithError(err).Error("failed retrieving custom commands for export")
+		http.Error(w, "failed retrieving custom commands", http.StatusInternalServerError)
+		return
+	}
+
+	fileName := fmt.Sprintf("customcommands-%d.json", activeGuild.ID)
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("content-disposition", "attachment; filename=\""+fileName+"\"")
+
+	// indented since bundles are meant to be shared and looked at
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	web.LogIgnoreErr(enc.Encode(ExportBundle(activeGuild, groups, ccs)))
+}
+
+// handleImportBundle creates the custom commands and groups in an uploaded or pasted bundle.
+// Either everything in the bundle is imported or nothing is.
+func handleImportBundle(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	raw, err := readBundleUpload(r)
+	if err != nil {
+		return templateData, err
+	}
+
+	bundle, err := DecodeBundle(raw)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert(err.Error())), nil
+	}
+
+	if len(bundle.Commands) < 1 {
+		return templateData.AddAlerts(web.ErrorAlert("The bundle has no custom commands")), nil
+	}
+
+	c, err := models.CustomCommands(qm.Where("guild_id = ?", activeGuild.ID)).CountG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	if int(c)+len(bundle.Commands) > MaxCommandsForContext(ctx) {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("The bundle has %d custom commands but there's only room for %d more, max %d custom commands allowed (or %d for premium servers)",
+			len(bundle.Commands), MaxCommandsForContext(ctx)-int(c), MaxCommands, MaxCommandsPremium))), nil
+	}
+
+	existingGroups, err := models.CustomCommandGroups(qm.Where("guild_id = ?", activeGuild.ID)).AllG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	groupIDs := make(map[string]int64)
+	for _, v := range existingGroups {
+		groupIDs[v.Name] = v.ID
+	}
+
+	remapper := newBundleRemapper(activeGuild)
+
+	// groups with the same name as an existing one are merged into it, keeping the existing restrictions
+	var newGroups []*models.CustomCommandGroup
+	for _, v := range bundle.Groups {
+		if _, ok := groupIDs[v.Name]; ok {
+			continue
+		}
+
+		if v.Name == "" || utf8.RuneCountInString(v.Name) > 100 {
+			return templateData.AddAlerts(web.ErrorAlert("Group names in the bundle have to be between 1 and 100 characters long")), nil
+		}
+
+		group, err := v.ToDBModel(remapper)
+		if err != nil {
+			return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Group %s in the bundle can't be imported, %s. Nothing was imported", v.Name, err))), nil
+		}
+
+		group.GuildID = activeGuild.ID
+		newGroups = append(newGroups, group)
+		groupIDs[v.Name] = 0
+	}
+
+	if len(existingGroups)+len(newGroups) > MaxGroups {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Importing the bundle would go over the max of %d custom command groups", MaxGroups))), nil
+	}
+
+	existingSlash, err := models.CustomCommands(qm.Select("text_trigger"), qm.Where("guild_id = ? AND trigger_type = ?", activeGuild.ID, int(CommandTriggerSlash))).AllG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	slashNames := make([]string, 0, len(existingSlash))
+	for _, v := range existingSlash {
+		slashNames = append(slashNames, v.TextTrigger)
+	}
+
+	slashLimit, err := commands.GuildSlashCommandsLimit(ctx, activeGuild.ID)
+	if err != nil {
+		return templateData, err
+	}
+
+	numLowIntervals, err := models.CustomCommands(qm.Where("guild_id = ? AND trigger_type = 5 AND time_trigger_interval <= 10", activeGuild.ID)).CountG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	ccs := make([]*models.CustomCommand, 0, len(bundle.Commands))
+	for i, v := range bundle.Commands {
+		form, err := v.ToForm(remapper)
+		if err != nil {
+			return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Custom command number %d in the bundle can't be imported, %s. Nothing was imported", i+1, err))), nil
+		}
+
+		if !web.ValidateForm(activeGuild, templateData, form) {
+			return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Custom command number %d in the bundle is invalid, nothing was imported", i+1))), nil
+		}
+
+		dbModel := form.ToDBModel()
+		dbModel.GuildID = activeGuild.ID
+		dbModel.Disabled = v.Disabled
+
+		if dbModel.TriggerType == int(CommandTriggerSlash) {
+			if common.ContainsStringSlice(slashNames, dbModel.TextTrigger) {
+				return templateData.AddAlerts(web.ErrorAlert("There's already a custom command with the slash command name ", dbModel.TextTrigger)), nil
+			}
+
+			slashNames = append(slashNames, dbModel.TextTrigger)
+			if len(slashNames) > slashLimit {
+				return templateData.AddAlerts(web.ErrorAlert(slashCommandLimitMessage(slashLimit))), nil
+			}
+		}
+
+		if dbModel.TriggerType == int(CommandTriggerInterval) && dbModel.TimeTriggerInterval <= 10 {
+			numLowIntervals++
+			if numLowIntervals > 5 {
+				return templateData.AddAlerts(web.ErrorAlert("You can have max 5 triggers on less than 10 minute intervals")), nil
+			}
+		}
+
+		ccs = append(ccs, dbModel)
+	}
+
+	tx, err := common.PQ.BeginTx(ctx, nil)
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	for _, v := range newGroups {
+		err = v.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return templateData, errors.WithStackIf(err)
+		}
+
+		groupIDs[v.Name] = v.ID
+	}
+
+	for i, v := range ccs {
+		// commands in groups that are neither in the bundle nor on the server end up ungrouped
+		if groupID := groupIDs[bundle.Commands[i].Group]; groupID != 0 {
+			v.GroupID = null.Int64From(groupID)
+		}
+
+		v.LocalID, err = common.GenLocalIncrID(activeGuild.ID, "custom_command")
+		if err != nil {
+			tx.Rollback()
+			return templateData, errors.WrapIf(err, "error generating local id")
+		}
+
+		err = v.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			tx.Rollback()
+			return templateData, errors.WithStackIf(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return templateData, errors.WithStackIf(err)
+	}
+
+	for _, v := range ccs {
+		err = SaveCommandRevision(ctx, activeGuild.ID, v.LocalID, nil, v.Responses, web.ContextUser(ctx).ID)
+		if err != nil {
+			web.CtxLogger(ctx).WithError(err).WithField("guild", activeGuild.ID).Error("failed saving custom command revision")
+		}
+
+		if v.TriggerType == int(CommandTriggerInterval) {
+			err = UpdateCommandNextRunTime(v, false, false)
+			if err != nil {
+				web.CtxLogger(ctx).WithError(err).WithField("guild", activeGuild.ID).Error("failed updating next custom command run time")
+			}
+		}
+	}
+
+	featureflags.MarkGuildDirty(activeGuild.ID)
+
+	go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyImportedBundle, &cplogs.Param{Type: cplogs.ParamTypeInt, Value: int64(len(ccs))}))
+
+	pubsub.EvictCacheSet(cachedCommandsMessage, activeGuild.ID)
+	commands.PubsubSendUpdateGuildSlashCommands(activeGuild.ID)
+
+	templateData.AddAlerts(web.SucessAlert(fmt.Sprintf("Imported %d custom commands and %d groups", len(ccs), len(newGroups))))
+	if len(remapper.MissingChannels) > 0 {
+		templateData.AddAlerts(web.WarningAlert("These channels weren't found on this server and were left out: ", strings.Join(remapper.MissingChannels, ", ")))
+	}
+	if len(remapper.MissingRoles) > 0 {
+		templateData.AddAlerts(web.WarningAlert("These roles weren't found on this server and were left out: ", strings.Join(remapper.MissingRoles, ", ")))
+	}
+
+	return templateData, nil
+}
+
+// readBundleUpload reads the bundle from either the uploaded file or the pasted json
+func readBundleUpload(r *http.Request) ([]byte, error) {
+	f, _, err := r.FormFile("Bundle")
+	if err == http.ErrMissingFile {
+		pasted := r.FormValue("BundleJSON")
+		if len(pasted) > MaxBundleSize {
+			return nil, web.NewPublicError("The bundle is too big")
+		}
+
+		return []byte(pasted), nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	raw, err := ioutil.ReadAll(io.LimitReader(f, MaxBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(raw) > MaxBundleSize {
+		return nil, web.NewPublicError("The bundle is too big")
+	}
+
+	return raw, nil
+}
+
 // allow for max 5 triggers with intervals of less than 10 minutes
 func checkIntervalLimits(ctx context.Context, guildID int64, cmdID int64, templateData web.TemplateData) (ok bool, err error) {
 	num, err := models.CustomCommands(qm.Where("guild_id = ? AND local_id != ? AND trigger_type = 5 AND time_trigger_interval <= 10", guildID, cmdID)).CountG(ctx)
@@ -410,6 +848,31 @@ func checkIntervalLimits(ctx context.Context, guildID int64, cmdID int64, templa
 	return false, nil
 }
 
+// checkSlashCommandLimit makes sure there's room for one more slash command on the server, going over discord's limit
+// would leave out the last slash commands when registering them
+func checkSlashCommandLimit(ctx context.Context, guildID int64, cmdID int64, templateData web.TemplateData) (ok bool, err error) {
+	limit, err := commands.GuildSlashCommandsLimit(ctx, guildID)
+	if err != nil {
+		return false, err
+	}
+
+	num, err := models.CustomCommands(qm.Where("guild_id = ? AND local_id != ? AND trigger_type = ?", guildID, cmdID, int(CommandTriggerSlash))).CountG(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if int(num) < limit {
+		return true, nil
+	}
+
+	templateData.AddAlerts(web.ErrorAlert(slashCommandLimitMessage(limit)))
+	return false, nil
+}
+
+func slashCommandLimitMessage(limit int) string {
+	return fmt.Sprintf("You can have max %d custom commands with slash command triggers, discord limits the number of slash commands on a server", limit)
+}
+
 func handleNewGroup(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
 	ctx := r.Context()
 	activeGuild, templateData := web.GetBaseCPContextData(ctx)
@@ -508,6 +971,22 @@ func triggerTypeFromForm(str string) CommandTriggerType {
 		return CommandTriggerCommand
 	case "reaction":
 		return CommandTriggerReaction
+	case "slash":
+		return CommandTriggerSlash
+	case "member_join":
+		return CommandTriggerMemberJoin
+	case "member_leave":
+		return CommandTriggerMemberLeave
+	case "role_added":
+		return CommandTriggerRoleAdded
+	case "role_removed":
+		return CommandTriggerRoleRemoved
+	case "nickname_change":
+		return CommandTriggerNicknameChange
+	case "message_delete":
+		return CommandTriggerMessageDelete
+	case "message_edit":
+		return CommandTriggerMessageEdit
 	case "interval_minutes", "interval_hours":
 		return CommandTriggerInterval
 	default:
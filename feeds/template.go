@@ -0,0 +1,101 @@
+package feeds
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/dstate/v3"
+	"github.com/jonas747/yagpdb/bot/botrest"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/templates"
+)
+
+const MaxMessageTemplateLength = 2000
+
+var ErrEmptyTemplateOutput = errors.New("message template produced no output")
+
+// MessageTemplateContextFuncs are the only context functions available in feed message templates,
+// feeds are posted outside of the bot so anything that sends messages, changes roles or touches
+// the database is left out
+var MessageTemplateContextFuncs = []string{
+	"mentionEveryone",
+	"mentionHere",
+	"mentionRoleID",
+	"mentionRoleName",
+
+	"reFind",
+	"reFindAll",
+	"reFindAllSubmatches",
+	"reReplace",
+	"reSplit",
+
+	"setEmbed",
+}
+
+func newTemplateContext(gs *dstate.GuildSet, cs *dstate.ChannelState, embed **discordgo.MessageEmbed) *templates.Context {
+	ctx := templates.NewContext(gs, cs, nil)
+	ctx.AllowedContextFuncs = MessageTemplateContextFuncs
+	ctx.ContextFuncs["setEmbed"] = func(values ...interface{}) (string, error) {
+		e, err := templates.CreateEmbed(values...)
+		if err != nil {
+			return "", err
+		}
+
+		*embed = e
+		return "", nil
+	}
+
+	return ctx
+}
+
+// ValidateMessageTemplate makes sure the template isn't too long and only uses the functions available in feed templates
+func ValidateMessageTemplate(source string) error {
+	if utf8.RuneCountInString(source) > MaxMessageTemplateLength {
+		return fmt.Errorf("message template too long (max %d)", MaxMessageTemplateLength)
+	}
+
+	var embed *discordgo.MessageEmbed
+	_, err := newTemplateContext(nil, nil, &embed).Parse(source)
+	return err
+}
+
+// ExecuteMessageTemplate runs a feed message template with the provided feed specific data,
+// the output is used as the message content and setEmbed sets the embed.
+func ExecuteMessageTemplate(guildID, channelID int64, name, source string, data map[string]interface{}) (*discordgo.MessageSend, error) {
+	gs, err := botrest.GetGuild(guildID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "botrest.GetGuild")
+	}
+
+	var embed *discordgo.MessageEmbed
+	ctx := newTemplateContext(gs, gs.GetChannel(channelID), &embed)
+	ctx.Name = name
+
+	// set up the message ourselves, otherwise the bot member is looked up in the state which isn't available here
+	ctx.Msg = &discordgo.Message{
+		GuildID:   guildID,
+		ChannelID: channelID,
+		Author:    common.BotUser,
+	}
+
+	for k, v := range data {
+		ctx.Data[k] = v
+	}
+
+	out, err := ctx.Execute(source)
+	if err != nil {
+		return nil, err
+	}
+
+	out = strings.TrimSpace(out)
+	if out == "" && embed == nil {
+		return nil, ErrEmptyTemplateOutput
+	}
+
+	send := ctx.MessageSend(common.CutStringShort(out, 2000))
+	send.Embed = embed
+	return send, nil
+}
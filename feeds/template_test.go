@@ -0,0 +1,34 @@
+package feeds
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMessageTemplate(t *testing.T) {
+	valid := []string{
+		"",
+		"New post: {{.Title}} {{.URL}}",
+		`{{mentionRoleName "news"}} {{setEmbed (sdict "title" .Title "url" .URL)}}`,
+		`{{reReplace "foo" .Title "bar"}}`,
+	}
+
+	for _, v := range valid {
+		if err := ValidateMessageTemplate(v); err != nil {
+			t.Errorf("expected %q to be valid, got: %v", v, err)
+		}
+	}
+
+	invalid := []string{
+		"{{.Title",
+		`{{sendMessage nil "hi"}}`,
+		`{{addRoleID 123}}`,
+		strings.Repeat("a", MaxMessageTemplateLength+1),
+	}
+
+	for _, v := range invalid {
+		if err := ValidateMessageTemplate(v); err == nil {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
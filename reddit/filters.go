@@ -0,0 +1,115 @@
+package reddit
+
+import (
+	"html"
+	"strings"
+
+	"github.com/jonas747/go-reddit"
+	"github.com/jonas747/yagpdb/reddit/models"
+)
+
+const (
+	FilterFlairNone    = 0 // allow posts with any flair
+	FilterFlairIgnore  = 1 // ignore posts with one of the listed flairs
+	FilterFlairRequire = 2 // only allow posts with one of the listed flairs
+
+	MaxFilterListEntries = 50
+	MaxFilterEntryLength = 100
+)
+
+// PostMatchesFilters returns true if the post passes the keyword, flair, author and spoiler filters of the feed
+func PostMatchesFilters(feed *models.RedditFeed, post *reddit.Link) bool {
+	if feed.SkipSpoilers && post.Spoiler {
+		return false
+	}
+
+	for _, v := range feed.AuthorBlacklist {
+		if strings.EqualFold(v, post.Author) {
+			return false
+		}
+	}
+
+	if feed.FilterFlair != FilterFlairNone {
+		hasFlair := false
+		for _, v := range feed.Flairs {
+			if strings.EqualFold(v, strings.TrimSpace(post.LinkFlairText)) {
+				hasFlair = true
+				break
+			}
+		}
+
+		if hasFlair && feed.FilterFlair == FilterFlairIgnore {
+			return false
+		} else if !hasFlair && feed.FilterFlair == FilterFlairRequire {
+			return false
+		}
+	}
+
+	if len(feed.IncludeKeywords) < 1 && len(feed.ExcludeKeywords) < 1 {
+		return true
+	}
+
+	content := strings.ToLower(html.UnescapeString(post.Title + "\n" + post.Selftext))
+	for _, v := range feed.ExcludeKeywords {
+		if strings.Contains(content, strings.ToLower(v)) {
+			return false
+		}
+	}
+
+	if len(feed.IncludeKeywords) < 1 {
+		return true
+	}
+
+	for _, v := range feed.IncludeKeywords {
+		if strings.Contains(content, strings.ToLower(v)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseFilterList parses a list of filter entries separated by newlines or commas,
+// empty and duplicate entries are removed
+func ParseFilterList(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '\n' || r == ','
+	})
+
+	result := make([]string, 0, len(fields))
+OUTER:
+	for _, v := range fields {
+		v = strings.TrimSpace(v)
+		if v == "" || len(v) > MaxFilterEntryLength {
+			continue
+		}
+
+		for _, existing := range result {
+			if strings.EqualFold(existing, v) {
+				continue OUTER
+			}
+		}
+
+		result = append(result, v)
+		if len(result) >= MaxFilterListEntries {
+			break
+		}
+	}
+
+	return result
+}
+
+// parseAuthorList is the same as ParseFilterList but also strips the u/ prefix
+func parseAuthorList(s string) []string {
+	authors := ParseFilterList(s)
+	for i, v := range authors {
+		v = strings.TrimPrefix(v, "/")
+		if len(v) > 2 && strings.EqualFold(v[:2], "u/") {
+			v = v[2:]
+		}
+
+		authors[i] = v
+	}
+
+	return authors
+}
@@ -0,0 +1,59 @@
+package reddit
+
+import (
+	"testing"
+
+	"github.com/jonas747/go-reddit"
+	"github.com/jonas747/yagpdb/reddit/models"
+)
+
+func TestPostMatchesFilters(t *testing.T) {
+	post := &reddit.Link{
+		Title:         "Patch 1.2 is out",
+		Selftext:      "Lots of balance changes",
+		Author:        "SomeDev",
+		LinkFlairText: "News",
+	}
+
+	cases := []struct {
+		name     string
+		feed     *models.RedditFeed
+		expected bool
+	}{
+		{"no filters", &models.RedditFeed{}, true},
+		{"include title", &models.RedditFeed{IncludeKeywords: []string{"patch"}}, true},
+		{"include selftext", &models.RedditFeed{IncludeKeywords: []string{"meme", "BALANCE"}}, true},
+		{"include miss", &models.RedditFeed{IncludeKeywords: []string{"meme"}}, false},
+		{"exclude", &models.RedditFeed{ExcludeKeywords: []string{"changes"}}, false},
+		{"exclude wins", &models.RedditFeed{IncludeKeywords: []string{"patch"}, ExcludeKeywords: []string{"1.2"}}, false},
+		{"author blacklist", &models.RedditFeed{AuthorBlacklist: []string{"somedev"}}, false},
+		{"flair ignore", &models.RedditFeed{FilterFlair: FilterFlairIgnore, Flairs: []string{"news"}}, false},
+		{"flair ignore other", &models.RedditFeed{FilterFlair: FilterFlairIgnore, Flairs: []string{"meme"}}, true},
+		{"flair require", &models.RedditFeed{FilterFlair: FilterFlairRequire, Flairs: []string{"News"}}, true},
+		{"flair require other", &models.RedditFeed{FilterFlair: FilterFlairRequire, Flairs: []string{"meme"}}, false},
+		{"skip spoilers", &models.RedditFeed{SkipSpoilers: true}, true},
+	}
+
+	for _, c := range cases {
+		if got := PostMatchesFilters(c.feed, post); got != c.expected {
+			t.Errorf("%s: expected %t, got %t", c.name, c.expected, got)
+		}
+	}
+
+	post.Spoiler = true
+	if PostMatchesFilters(&models.RedditFeed{SkipSpoilers: true}, post) {
+		t.Error("expected spoiler to be skipped")
+	}
+}
+
+func TestParseFilterList(t *testing.T) {
+	parsed := ParseFilterList(" foo, bar\nFOO,,baz \n")
+	if len(parsed) != 3 || parsed[0] != "foo" || parsed[1] != "bar" || parsed[2] != "baz" {
+		t.Errorf("unexpected result: %q", parsed)
+	}
+
+	authors := parseAuthorList("u/someone, /u/other, user")
+	if len(authors) != 3 || authors[0] != "someone" || authors[1] != "other" || authors[2] != "user" {
+		t.Errorf("unexpected result: %q", authors)
+	}
+}
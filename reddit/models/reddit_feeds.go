@@ -19,44 +19,66 @@ import (
 	"github.com/volatiletech/sqlboiler/queries/qm"
 	"github.com/volatiletech/sqlboiler/queries/qmhelper"
 	"github.com/volatiletech/sqlboiler/strmangle"
+	"github.com/volatiletech/sqlboiler/types"
 )
 
 // RedditFeed is an object representing the database table.
 type RedditFeed struct {
-	ID         int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
-	GuildID    int64  `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
-	ChannelID  int64  `boil:"channel_id" json:"channel_id" toml:"channel_id" yaml:"channel_id"`
-	Subreddit  string `boil:"subreddit" json:"subreddit" toml:"subreddit" yaml:"subreddit"`
-	FilterNSFW int    `boil:"filter_nsfw" json:"filter_nsfw" toml:"filter_nsfw" yaml:"filter_nsfw"`
-	MinUpvotes int    `boil:"min_upvotes" json:"min_upvotes" toml:"min_upvotes" yaml:"min_upvotes"`
-	UseEmbeds  bool   `boil:"use_embeds" json:"use_embeds" toml:"use_embeds" yaml:"use_embeds"`
-	Slow       bool   `boil:"slow" json:"slow" toml:"slow" yaml:"slow"`
-	Disabled   bool   `boil:"disabled" json:"disabled" toml:"disabled" yaml:"disabled"`
+	ID              int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	GuildID         int64             `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
+	ChannelID       int64             `boil:"channel_id" json:"channel_id" toml:"channel_id" yaml:"channel_id"`
+	Subreddit       string            `boil:"subreddit" json:"subreddit" toml:"subreddit" yaml:"subreddit"`
+	FilterNSFW      int               `boil:"filter_nsfw" json:"filter_nsfw" toml:"filter_nsfw" yaml:"filter_nsfw"`
+	MinUpvotes      int               `boil:"min_upvotes" json:"min_upvotes" toml:"min_upvotes" yaml:"min_upvotes"`
+	UseEmbeds       bool              `boil:"use_embeds" json:"use_embeds" toml:"use_embeds" yaml:"use_embeds"`
+	Slow            bool              `boil:"slow" json:"slow" toml:"slow" yaml:"slow"`
+	Disabled        bool              `boil:"disabled" json:"disabled" toml:"disabled" yaml:"disabled"`
+	MessageTemplate string            `boil:"message_template" json:"message_template" toml:"message_template" yaml:"message_template"`
+	IncludeKeywords types.StringArray `boil:"include_keywords" json:"include_keywords" toml:"include_keywords" yaml:"include_keywords"`
+	ExcludeKeywords types.StringArray `boil:"exclude_keywords" json:"exclude_keywords" toml:"exclude_keywords" yaml:"exclude_keywords"`
+	FilterFlair     int               `boil:"filter_flair" json:"filter_flair" toml:"filter_flair" yaml:"filter_flair"`
+	Flairs          types.StringArray `boil:"flairs" json:"flairs" toml:"flairs" yaml:"flairs"`
+	AuthorBlacklist types.StringArray `boil:"author_blacklist" json:"author_blacklist" toml:"author_blacklist" yaml:"author_blacklist"`
+	SkipSpoilers    bool              `boil:"skip_spoilers" json:"skip_spoilers" toml:"skip_spoilers" yaml:"skip_spoilers"`
 
 	R *redditFeedR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L redditFeedL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var RedditFeedColumns = struct {
-	ID         string
-	GuildID    string
-	ChannelID  string
-	Subreddit  string
-	FilterNSFW string
-	MinUpvotes string
-	UseEmbeds  string
-	Slow       string
-	Disabled   string
+	ID              string
+	GuildID         string
+	ChannelID       string
+	Subreddit       string
+	FilterNSFW      string
+	MinUpvotes      string
+	UseEmbeds       string
+	Slow            string
+	Disabled        string
+	MessageTemplate string
+	IncludeKeywords string
+	ExcludeKeywords string
+	FilterFlair     string
+	Flairs          string
+	AuthorBlacklist string
+	SkipSpoilers    string
 }{
-	ID:         "id",
-	GuildID:    "guild_id",
-	ChannelID:  "channel_id",
-	Subreddit:  "subreddit",
-	FilterNSFW: "filter_nsfw",
-	MinUpvotes: "min_upvotes",
-	UseEmbeds:  "use_embeds",
-	Slow:       "slow",
-	Disabled:   "disabled",
+	ID:              "id",
+	GuildID:         "guild_id",
+	ChannelID:       "channel_id",
+	Subreddit:       "subreddit",
+	FilterNSFW:      "filter_nsfw",
+	MinUpvotes:      "min_upvotes",
+	UseEmbeds:       "use_embeds",
+	Slow:            "slow",
+	Disabled:        "disabled",
+	MessageTemplate: "message_template",
+	IncludeKeywords: "include_keywords",
+	ExcludeKeywords: "exclude_keywords",
+	FilterFlair:     "filter_flair",
+	Flairs:          "flairs",
+	AuthorBlacklist: "author_blacklist",
+	SkipSpoilers:    "skip_spoilers",
 }
 
 // Generated where
@@ -97,26 +119,61 @@ func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field
 func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
+type whereHelpertypes_StringArray struct{ field string }
+
+func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
 var RedditFeedWhere = struct {
-	ID         whereHelperint64
-	GuildID    whereHelperint64
-	ChannelID  whereHelperint64
-	Subreddit  whereHelperstring
-	FilterNSFW whereHelperint
-	MinUpvotes whereHelperint
-	UseEmbeds  whereHelperbool
-	Slow       whereHelperbool
-	Disabled   whereHelperbool
+	ID              whereHelperint64
+	GuildID         whereHelperint64
+	ChannelID       whereHelperint64
+	Subreddit       whereHelperstring
+	FilterNSFW      whereHelperint
+	MinUpvotes      whereHelperint
+	UseEmbeds       whereHelperbool
+	Slow            whereHelperbool
+	Disabled        whereHelperbool
+	MessageTemplate whereHelperstring
+	IncludeKeywords whereHelpertypes_StringArray
+	ExcludeKeywords whereHelpertypes_StringArray
+	FilterFlair     whereHelperint
+	Flairs          whereHelpertypes_StringArray
+	AuthorBlacklist whereHelpertypes_StringArray
+	SkipSpoilers    whereHelperbool
 }{
-	ID:         whereHelperint64{field: "\"reddit_feeds\".\"id\""},
-	GuildID:    whereHelperint64{field: "\"reddit_feeds\".\"guild_id\""},
-	ChannelID:  whereHelperint64{field: "\"reddit_feeds\".\"channel_id\""},
-	Subreddit:  whereHelperstring{field: "\"reddit_feeds\".\"subreddit\""},
-	FilterNSFW: whereHelperint{field: "\"reddit_feeds\".\"filter_nsfw\""},
-	MinUpvotes: whereHelperint{field: "\"reddit_feeds\".\"min_upvotes\""},
-	UseEmbeds:  whereHelperbool{field: "\"reddit_feeds\".\"use_embeds\""},
-	Slow:       whereHelperbool{field: "\"reddit_feeds\".\"slow\""},
-	Disabled:   whereHelperbool{field: "\"reddit_feeds\".\"disabled\""},
+	ID:              whereHelperint64{field: "\"reddit_feeds\".\"id\""},
+	GuildID:         whereHelperint64{field: "\"reddit_feeds\".\"guild_id\""},
+	ChannelID:       whereHelperint64{field: "\"reddit_feeds\".\"channel_id\""},
+	Subreddit:       whereHelperstring{field: "\"reddit_feeds\".\"subreddit\""},
+	FilterNSFW:      whereHelperint{field: "\"reddit_feeds\".\"filter_nsfw\""},
+	MinUpvotes:      whereHelperint{field: "\"reddit_feeds\".\"min_upvotes\""},
+	UseEmbeds:       whereHelperbool{field: "\"reddit_feeds\".\"use_embeds\""},
+	Slow:            whereHelperbool{field: "\"reddit_feeds\".\"slow\""},
+	Disabled:        whereHelperbool{field: "\"reddit_feeds\".\"disabled\""},
+	MessageTemplate: whereHelperstring{field: "\"reddit_feeds\".\"message_template\""},
+	IncludeKeywords: whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"include_keywords\""},
+	ExcludeKeywords: whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"exclude_keywords\""},
+	FilterFlair:     whereHelperint{field: "\"reddit_feeds\".\"filter_flair\""},
+	Flairs:          whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"flairs\""},
+	AuthorBlacklist: whereHelpertypes_StringArray{field: "\"reddit_feeds\".\"author_blacklist\""},
+	SkipSpoilers:    whereHelperbool{field: "\"reddit_feeds\".\"skip_spoilers\""},
 }
 
 // RedditFeedRels is where relationship names are stored.
@@ -136,9 +193,9 @@ func (*redditFeedR) NewStruct() *redditFeedR {
 type redditFeedL struct{}
 
 var (
-	redditFeedAllColumns            = []string{"id", "guild_id", "channel_id", "subreddit", "filter_nsfw", "min_upvotes", "use_embeds", "slow", "disabled"}
+	redditFeedAllColumns            = []string{"id", "guild_id", "channel_id", "subreddit", "filter_nsfw", "min_upvotes", "use_embeds", "slow", "disabled", "message_template", "include_keywords", "exclude_keywords", "filter_flair", "flairs", "author_blacklist", "skip_spoilers"}
 	redditFeedColumnsWithoutDefault = []string{"guild_id", "channel_id", "subreddit", "filter_nsfw", "min_upvotes", "use_embeds", "slow"}
-	redditFeedColumnsWithDefault    = []string{"id", "disabled"}
+	redditFeedColumnsWithDefault    = []string{"id", "disabled", "message_template", "include_keywords", "exclude_keywords", "filter_flair", "flairs", "author_blacklist", "skip_spoilers"}
 	redditFeedPrimaryKeyColumns     = []string{"id"}
 )
 
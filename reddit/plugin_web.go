@@ -13,6 +13,7 @@ import (
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/cplogs"
 	"github.com/jonas747/yagpdb/common/pubsub"
+	"github.com/jonas747/yagpdb/feeds"
 	"github.com/jonas747/yagpdb/reddit/models"
 	"github.com/jonas747/yagpdb/web"
 	"github.com/volatiletech/sqlboiler/boil"
@@ -35,6 +36,15 @@ type CreateForm struct {
 	UseEmbeds  bool   `schema:"use_embeds"`
 	NSFWMode   int    `schema:"nsfw_filter"`
 	MinUpvotes int    `schema:"min_upvotes"`
+
+	MessageTemplate string `schema:"message_template"`
+
+	IncludeKeywords string `schema:"include_keywords"`
+	ExcludeKeywords string `schema:"exclude_keywords"`
+	FlairMode       int    `schema:"flair_filter" valid:"0,2"`
+	Flairs          string `schema:"flairs"`
+	AuthorBlacklist string `schema:"author_blacklist"`
+	SkipSpoilers    bool   `schema:"skip_spoilers"`
 }
 
 type UpdateForm struct {
@@ -43,6 +53,15 @@ type UpdateForm struct {
 	UseEmbeds  bool  `schema:"use_embeds"`
 	NSFWMode   int   `schema:"nsfw_filter"`
 	MinUpvotes int   `schema:"min_upvotes"`
+
+	MessageTemplate string `schema:"message_template"`
+
+	IncludeKeywords string `schema:"include_keywords"`
+	ExcludeKeywords string `schema:"exclude_keywords"`
+	FlairMode       int    `schema:"flair_filter" valid:"0,2"`
+	Flairs          string `schema:"flairs"`
+	AuthorBlacklist string `schema:"author_blacklist"`
+	SkipSpoilers    bool   `schema:"skip_spoilers"`
 }
 
 var (
@@ -129,12 +148,25 @@ func HandleNew(w http.ResponseWriter, r *http.Request) interface{} {
 		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d feeds allowed (or %d for premium servers)", GuildMaxFeedsNormal, GuildMaxFeedsPremium)))
 	}
 
+	if err := feeds.ValidateMessageTemplate(newElem.MessageTemplate); err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid message template: " + err.Error()))
+	}
+
 	watchItem := &models.RedditFeed{
 		GuildID:    activeGuild.ID,
 		ChannelID:  newElem.Channel,
 		Subreddit:  strings.ToLower(strings.TrimSpace(newElem.Subreddit)),
 		UseEmbeds:  newElem.UseEmbeds,
 		FilterNSFW: newElem.NSFWMode,
+
+		MessageTemplate: strings.TrimSpace(newElem.MessageTemplate),
+
+		IncludeKeywords: ParseFilterList(newElem.IncludeKeywords),
+		ExcludeKeywords: ParseFilterList(newElem.ExcludeKeywords),
+		FilterFlair:     newElem.FlairMode,
+		Flairs:          ParseFilterList(newElem.Flairs),
+		AuthorBlacklist: parseAuthorList(newElem.AuthorBlacklist),
+		SkipSpoilers:    newElem.SkipSpoilers,
 	}
 
 	if newElem.Slow {
@@ -182,15 +214,27 @@ func HandleModify(w http.ResponseWriter, r *http.Request) interface{} {
 		return templateData.AddAlerts(web.ErrorAlert("Unknown id"))
 	}
 
+	if err := feeds.ValidateMessageTemplate(updated.MessageTemplate); err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid message template: " + err.Error()))
+	}
+
 	item.ChannelID = updated.Channel
 	item.UseEmbeds = updated.UseEmbeds
 	item.FilterNSFW = updated.NSFWMode
+	item.MessageTemplate = strings.TrimSpace(updated.MessageTemplate)
+	item.IncludeKeywords = ParseFilterList(updated.IncludeKeywords)
+	item.ExcludeKeywords = ParseFilterList(updated.ExcludeKeywords)
+	item.FilterFlair = updated.FlairMode
+	item.Flairs = ParseFilterList(updated.Flairs)
+	item.AuthorBlacklist = parseAuthorList(updated.AuthorBlacklist)
+	item.SkipSpoilers = updated.SkipSpoilers
 	item.Disabled = false
 	if item.Slow {
 		item.MinUpvotes = updated.MinUpvotes
 	}
 
-	_, err := item.UpdateG(ctx, boil.Whitelist("channel_id", "use_embeds", "filter_nsfw", "min_upvotes", "disabled"))
+	_, err := item.UpdateG(ctx, boil.Whitelist("channel_id", "use_embeds", "filter_nsfw", "min_upvotes", "message_template",
+		"include_keywords", "exclude_keywords", "filter_flair", "flairs", "author_blacklist", "skip_spoilers", "disabled"))
 	if web.CheckErr(templateData, err, "Failed saving item :'(", web.CtxLogger(ctx).Error) {
 		return templateData
 	}
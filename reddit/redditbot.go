@@ -214,7 +214,16 @@ func (p *PostHandlerImpl) handlePost(post *reddit.Link, filterGuild int64) error
 			},
 		}
 
-		if item.UseEmbeds {
+		custom, err := executeMessageTemplate(item, post)
+		if err == feeds.ErrEmptyTemplateOutput {
+			continue
+		}
+
+		if custom != nil {
+			qm.MessageStr = custom.Content
+			qm.MessageEmbed = custom.Embed
+			qm.AllowedMentions = custom.AllowedMentions
+		} else if item.UseEmbeds {
 			qm.MessageEmbed = embed
 		} else {
 			qm.MessageStr = message
@@ -241,16 +250,6 @@ OUTER:
 			}
 		}
 
-		limit := confMaxPostsHourFast.GetInt()
-		if p.Slow {
-			limit = confMaxPostsHourSlow.GetInt()
-		}
-
-		// apply ratelimiting
-		if !p.ratelimiter.CheckIncrement(time.Now(), c.GuildID, limit) {
-			continue
-		}
-
 		if post.Over18 && c.FilterNSFW == FilterNSFWIgnore {
 			// NSFW and we ignore nsfw posts
 			continue
@@ -266,6 +265,20 @@ OUTER:
 			}
 		}
 
+		if !PostMatchesFilters(c, post) {
+			continue
+		}
+
+		limit := confMaxPostsHourFast.GetInt()
+		if p.Slow {
+			limit = confMaxPostsHourSlow.GetInt()
+		}
+
+		// apply ratelimiting last so filtered out posts don't count towards the limit
+		if !p.ratelimiter.CheckIncrement(time.Now(), c.GuildID, limit) {
+			continue
+		}
+
 		filteredItems = append(filteredItems, c)
 	}
 
@@ -372,6 +385,45 @@ func CreatePostMessage(post *reddit.Link) (string, *discordgo.MessageEmbed) {
 	return plainMessage, embed
 }
 
+// TemplateData returns the data available in custom feed message templates
+func TemplateData(post *reddit.Link) map[string]interface{} {
+	thumbnail := ""
+	if post.PostHint == "image" && !post.Spoiler {
+		thumbnail = post.URL
+	}
+
+	return map[string]interface{}{
+		"Title":     html.UnescapeString(post.Title),
+		"Author":    post.Author,
+		"URL":       "https://redd.it/" + post.ID,
+		"Link":      post.URL,
+		"Thumbnail": thumbnail,
+		"Subreddit": post.Subreddit,
+		"Flair":     post.LinkFlairText,
+		"Text":      common.CutStringShort(html.UnescapeString(post.Selftext), 1000),
+		"IsSelf":    post.IsSelf,
+		"IsNSFW":    post.Over18,
+		"IsSpoiler": post.Spoiler,
+		"Score":     post.Score,
+	}
+}
+
+// executeMessageTemplate runs the custom message template of the feed if it has one,
+// if it fails the standard message is posted instead
+func executeMessageTemplate(feed *models.RedditFeed, post *reddit.Link) (*discordgo.MessageSend, error) {
+	if feed.MessageTemplate == "" {
+		return nil, nil
+	}
+
+	msg, err := feeds.ExecuteMessageTemplate(feed.GuildID, feed.ChannelID, "reddit_feed", feed.MessageTemplate, TemplateData(post))
+	if err != nil && err != feeds.ErrEmptyTemplateOutput {
+		logger.WithError(err).WithField("guild", feed.GuildID).Warn("failed executing reddit feed message template")
+		return nil, nil
+	}
+
+	return msg, err
+}
+
 type RedditIdSlice []string
 
 // Len is the number of elements in the collection.
@@ -21,4 +21,19 @@ CREATE INDEX IF NOT EXISTS redidt_feeds_subreddit_idx ON reddit_feeds(subreddit)
 
 `, `
 ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT FALSE;
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS message_template TEXT NOT NULL DEFAULT '';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS include_keywords TEXT[] NOT NULL DEFAULT '{}';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS exclude_keywords TEXT[] NOT NULL DEFAULT '{}';
+`, `
+-- 0 = none, 1 = ignore the listed flairs, 2 = only the listed flairs
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS filter_flair INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS flairs TEXT[] NOT NULL DEFAULT '{}';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS author_blacklist TEXT[] NOT NULL DEFAULT '{}';
+`, `
+ALTER TABLE reddit_feeds ADD COLUMN IF NOT EXISTS skip_spoilers BOOLEAN NOT NULL DEFAULT FALSE;
 `}
@@ -0,0 +1,60 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/yagpdb/bot"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/mqueue"
+	"github.com/jonas747/yagpdb/rss/models"
+)
+
+func (p *Plugin) Status() (string, string) {
+	numFeeds, err := models.RssFeeds(models.RssFeedWhere.Enabled.EQ(true)).CountG(context.Background())
+	if err != nil {
+		logger.WithError(err).Error("failed fetching status")
+		return "RSS feeds", "error"
+	}
+
+	return "RSS feeds", fmt.Sprintf("%d", numFeeds)
+}
+
+var _ mqueue.PluginWithSourceDisabler = (*Plugin)(nil)
+
+// DisableFeed disables feeds the bot can't post in anymore
+func (p *Plugin) DisableFeed(elem *mqueue.QueuedElement, sendErr error) {
+	feedID, err := strconv.ParseInt(elem.SourceID, 10, 64)
+	if err != nil {
+		logger.WithError(err).WithField("source_id", elem.SourceID).Error("failed parsing sourceID")
+		return
+	}
+
+	reason := "Failed posting in the channel"
+	if sendErr != nil {
+		reason += ": " + sendErr.Error()
+	}
+
+	_, err = models.RssFeeds(models.RssFeedWhere.ID.EQ(feedID)).UpdateAllG(context.Background(), models.M{
+		"enabled":    false,
+		"last_error": common.CutStringShort(reason, 250),
+	})
+	if err != nil {
+		logger.WithError(err).WithField("feed_id", feedID).Error("failed disabling feed")
+	}
+}
+
+var _ bot.RemoveGuildHandler = (*Plugin)(nil)
+
+func (p *Plugin) RemoveGuild(g int64) error {
+	_, err := models.RssFeeds(models.RssFeedWhere.GuildID.EQ(g)).UpdateAllG(context.Background(), models.M{
+		"enabled": false,
+	})
+	if err != nil {
+		return errors.WrapIf(err, "failed disabling rss feeds")
+	}
+
+	return nil
+}
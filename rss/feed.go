@@ -0,0 +1,310 @@
+package rss
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/analytics"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/mqueue"
+	"github.com/jonas747/yagpdb/feeds"
+	"github.com/jonas747/yagpdb/rss/models"
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+const (
+	// max number of feeds checked each run of the poll loop
+	maxFeedsPerRun     = 200
+	pollLoopInterval   = time.Second * 30
+	concurrentFetchers = 10
+
+	// if a feed has more new items than this, only the newest ones are posted
+	maxItemsPerCheck = 5
+
+	// feeds failing this many times in a row are disabled
+	maxConsecutiveErrors = 50
+
+	// items that haven't been seen in a feed for this long are forgotten
+	seenItemsRetention = time.Hour * 24 * 30
+)
+
+var _ feeds.Plugin = (*Plugin)(nil)
+
+func (p *Plugin) StartFeed() {
+	p.Stop = make(chan *sync.WaitGroup)
+	p.runPollLoop()
+}
+
+func (p *Plugin) StopFeed(wg *sync.WaitGroup) {
+	if p.Stop != nil {
+		p.Stop <- wg
+	} else {
+		wg.Done()
+	}
+}
+
+func (p *Plugin) runPollLoop() {
+	ticker := time.NewTicker(pollLoopInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case wg := <-p.Stop:
+			wg.Done()
+			logger.Info("rss poll loop shut down")
+			return
+		case <-ticker.C:
+			started := time.Now()
+			n, err := p.checkDueFeeds()
+			if err != nil {
+				logger.WithError(err).Error("failed checking feeds")
+			} else if n > 0 {
+				logger.Infof("Took %s to check %d rss feeds", time.Since(started), n)
+			}
+		}
+	}
+}
+
+// checkDueFeeds checks all the feeds that haven't been checked in the poll interval,
+// feeds with the same url are only fetched once
+func (p *Plugin) checkDueFeeds() (int, error) {
+	interval := time.Minute * time.Duration(confPollInterval.GetInt())
+
+	due, err := models.RssFeeds(
+		models.RssFeedWhere.Enabled.EQ(true),
+		qm.Where("(last_checked_at IS NULL OR last_checked_at < ?)", time.Now().Add(-interval)),
+		qm.OrderBy("last_checked_at ASC NULLS FIRST"),
+		qm.Limit(maxFeedsPerRun)).AllG(context.Background())
+	if err != nil {
+		return 0, errors.WithStackIf(err)
+	}
+
+	byURL := make(map[string][]*models.RssFeed)
+	urls := make([]string, 0, len(due))
+	for _, v := range due {
+		if _, ok := byURL[v.URL]; !ok {
+			urls = append(urls, v.URL)
+		}
+
+		byURL[v.URL] = append(byURL[v.URL], v)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan bool, concurrentFetchers)
+	for _, u := range urls {
+		wg.Add(1)
+		sem <- true
+
+		go func(feedURL string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			p.checkURL(feedURL, byURL[feedURL])
+		}(u)
+	}
+
+	wg.Wait()
+	return len(due), nil
+}
+
+func (p *Plugin) checkURL(feedURL string, subs []*models.RssFeed) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	parsed, fetchErr := FetchFeed(ctx, feedURL)
+	for _, sub := range subs {
+		var err error
+		if fetchErr != nil {
+			err = markFeedFailed(sub, fetchErr)
+		} else {
+			err = p.handleFeed(sub, parsed)
+		}
+
+		if err != nil {
+			logger.WithError(err).WithField("feed_id", sub.ID).Error("failed updating rss feed")
+		}
+	}
+}
+
+func markFeedFailed(sub *models.RssFeed, fetchErr error) error {
+	sub.LastCheckedAt = null.TimeFrom(time.Now())
+	sub.ConsecutiveErrors++
+	sub.LastError = common.CutStringShort(fetchErr.Error(), 250)
+
+	if sub.ConsecutiveErrors >= maxConsecutiveErrors {
+		logger.WithError(fetchErr).WithField("feed_id", sub.ID).Info("disabling broken rss feed")
+		sub.Enabled = false
+	}
+
+	_, err := sub.UpdateG(context.Background(), boil.Whitelist("last_checked_at", "consecutive_errors", "last_error", "enabled"))
+	return errors.WithStackIf(err)
+}
+
+// handleFeed posts the new items in the feed, on the first check all the items are just marked as seen
+func (p *Plugin) handleFeed(sub *models.RssFeed, parsed *Feed) error {
+	now := time.Now()
+
+	guids := make([]string, 0, len(parsed.Items))
+	unique := make(map[string]*FeedItem, len(parsed.Items))
+	for _, v := range parsed.Items {
+		if _, ok := unique[v.GUID]; ok {
+			continue
+		}
+
+		unique[v.GUID] = v
+		guids = append(guids, v.GUID)
+	}
+
+	if len(guids) > 0 {
+		seen, err := seenGUIDs(sub.ID, guids)
+		if err != nil {
+			return err
+		}
+
+		newItems := make([]*FeedItem, 0)
+		for _, guid := range guids {
+			if !common.ContainsStringSlice(seen, guid) {
+				newItems = append(newItems, unique[guid])
+			}
+		}
+
+		err = markSeen(sub.ID, guids, now)
+		if err != nil {
+			return err
+		}
+
+		if sub.LastCheckedAt.Valid && len(newItems) > 0 {
+			SortOldestFirst(newItems)
+			if len(newItems) > maxItemsPerCheck {
+				newItems = newItems[len(newItems)-maxItemsPerCheck:]
+			}
+
+			feedTitle := sub.Title
+			if feedTitle == "" {
+				feedTitle = parsed.Title
+			}
+
+			for _, item := range newItems {
+				p.queueItem(sub, feedTitle, item)
+			}
+		}
+	}
+
+	_, err := common.PQ.Exec("DELETE FROM rss_feed_seen_items WHERE feed_id = $1 AND last_seen_at < $2", sub.ID, now.Add(-seenItemsRetention))
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	sub.LastCheckedAt = null.TimeFrom(now)
+	sub.ConsecutiveErrors = 0
+	sub.LastError = ""
+	if sub.Title == "" {
+		sub.Title = common.CutStringShort(parsed.Title, 100)
+	}
+
+	_, err = sub.UpdateG(context.Background(), boil.Whitelist("last_checked_at", "consecutive_errors", "last_error", "title"))
+	return errors.WithStackIf(err)
+}
+
+func seenGUIDs(feedID int64, guids []string) ([]string, error) {
+	rows, err := common.PQ.Query("SELECT guid FROM rss_feed_seen_items WHERE feed_id = $1 AND guid = ANY($2)", feedID, pq.Array(guids))
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var guid string
+		err = rows.Scan(&guid)
+		if err != nil {
+			return nil, errors.WithStackIf(err)
+		}
+
+		result = append(result, guid)
+	}
+
+	return result, errors.WithStackIf(rows.Err())
+}
+
+func markSeen(feedID int64, guids []string, t time.Time) error {
+	const q = `INSERT INTO rss_feed_seen_items (feed_id, guid, last_seen_at)
+	SELECT $1, unnest($2::text[]), $3
+	ON CONFLICT (feed_id, guid) DO UPDATE SET last_seen_at = $3`
+
+	_, err := common.PQ.Exec(q, feedID, pq.Array(guids), t)
+	return errors.WithStackIf(err)
+}
+
+func (p *Plugin) queueItem(sub *models.RssFeed, feedTitle string, item *FeedItem) {
+	go analytics.RecordActiveUnit(sub.GuildID, p, "posted_rss_message")
+	feeds.MetricPostedMessages.With(prometheus.Labels{"source": "rss"}).Inc()
+
+	mqueue.QueueMessage(&mqueue.QueuedElement{
+		Source:   "rss",
+		SourceID: strconv.FormatInt(sub.ID, 10),
+
+		Guild:   sub.GuildID,
+		Channel: sub.ChannelID,
+
+		MessageEmbed: CreateItemEmbed(feedTitle, item),
+		Priority:     2,
+	})
+}
+
+// CreateItemEmbed creates the embed posted for new feed items
+func CreateItemEmbed(feedTitle string, item *FeedItem) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       common.CutStringShort(item.Title, 256),
+		Description: common.CutStringShort(item.Description, 500),
+		Color:       0xf26522,
+	}
+
+	if isHTTPURL(item.Link) {
+		embed.URL = item.Link
+	}
+
+	if embed.Title == "" {
+		embed.Title = common.CutStringShort(item.Link, 256)
+	}
+
+	if item.Author != "" {
+		embed.Author = &discordgo.MessageEmbedAuthor{
+			Name: common.CutStringShort(item.Author, 256),
+		}
+	}
+
+	if isHTTPURL(item.ImageURL) {
+		embed.Image = &discordgo.MessageEmbedImage{
+			URL: item.ImageURL,
+		}
+	}
+
+	if feedTitle != "" {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: common.CutStringShort(feedTitle, 256),
+		}
+	}
+
+	if !item.Published.IsZero() {
+		embed.Timestamp = item.Published.Format(time.RFC3339)
+	}
+
+	return embed
+}
+
+// discord rejects embeds with relative or non http urls
+func isHTTPURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
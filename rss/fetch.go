@@ -0,0 +1,140 @@
+package rss
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/yagpdb/common"
+)
+
+const maxFeedSize = 5 << 20
+
+var (
+	ErrInvalidURL        = errors.New("invalid url, only http and https urls are supported")
+	ErrNotAllowedAddress = errors.New("the feed url points to a local or private address")
+	ErrFeedTooBig        = errors.New("the feed is too big")
+)
+
+// the urls are provided by users, so make sure we don't connect to anything in our own network
+var feedHTTPClient = &http.Client{
+	Timeout: time.Second * 20,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   checkDialAddress,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return errors.New("stopped after 5 redirects")
+		}
+
+		_, err := ValidateFeedURL(req.URL.String())
+		return err
+	},
+}
+
+// ValidateFeedURL checks that the url is a absolute http or https url and returns it normalized
+func ValidateFeedURL(feedURL string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(feedURL))
+	if err != nil {
+		return "", ErrInvalidURL
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return "", ErrInvalidURL
+	}
+
+	parsed.Fragment = ""
+	return parsed.String(), nil
+}
+
+// FetchFeed downloads and parses the feed at feedURL
+func FetchFeed(ctx context.Context, feedURL string) (*Feed, error) {
+	req, err := http.NewRequest("GET", feedURL, nil)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", "YAGPDB RSS Feeds (+https://"+common.ConfHost.GetString()+")")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/feed+json, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.8")
+
+	resp, err := feedHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) > maxFeedSize {
+		return nil, ErrFeedTooBig
+	}
+
+	return ParseFeed(body)
+}
+
+func checkDialAddress(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return ErrNotAllowedAddress
+	}
+
+	return nil
+}
+
+var privateNetworks = mustParseCIDRs("0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12", "192.168.0.0/16", "198.18.0.0/15", "fc00::/7")
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	result := make([]*net.IPNet, 0, len(cidrs))
+	for _, v := range cidrs {
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			panic(err)
+		}
+
+		result = append(result, n)
+	}
+
+	return result
+}
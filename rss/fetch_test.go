@@ -0,0 +1,48 @@
+package rss
+
+import (
+	"net"
+	"testing"
+)
+
+func TestValidateFeedURL(t *testing.T) {
+	valid := map[string]string{
+		"https://example.com/feed.xml":        "https://example.com/feed.xml",
+		" http://example.com/rss?x=1#frag   ": "http://example.com/rss?x=1",
+	}
+
+	for in, expected := range valid {
+		got, err := ValidateFeedURL(in)
+		if err != nil || got != expected {
+			t.Errorf("ValidateFeedURL(%q): got %q, %v expected %q", in, got, err, expected)
+		}
+	}
+
+	for _, v := range []string{"", "example.com/feed", "ftp://example.com/feed", "file:///etc/passwd", "https://"} {
+		if _, err := ValidateFeedURL(v); err == nil {
+			t.Errorf("ValidateFeedURL(%q): expected an error", v)
+		}
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"1.1.1.1":      true,
+		"2606:4700::1": true,
+		"127.0.0.1":    false,
+		"::1":          false,
+		"10.1.2.3":     false,
+		"172.20.0.1":   false,
+		"192.168.1.1":  false,
+		"169.254.0.1":  false,
+		"0.0.0.0":      false,
+		"fd00::1":      false,
+		"100.64.0.1":   false,
+	}
+
+	for ip, expected := range cases {
+		if got := isPublicIP(net.ParseIP(ip)); got != expected {
+			t.Errorf("isPublicIP(%s): got %t, expected %t", ip, got, expected)
+		}
+	}
+}
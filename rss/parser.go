@@ -0,0 +1,484 @@
+package rss
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"golang.org/x/net/html/charset"
+)
+
+var (
+	ErrUnknownFormat = errors.New("not a RSS, Atom or JSON feed")
+)
+
+// Feed is a parsed feed regardless of the format it was in
+type Feed struct {
+	Title string
+	Link  string
+	Items []*FeedItem
+}
+
+// FeedItem is a single item (rss) or entry (atom) in a feed
+type FeedItem struct {
+	GUID        string
+	Title       string
+	Link        string
+	Description string
+	Author      string
+	ImageURL    string
+	Published   time.Time
+}
+
+// ParseFeed parses a RSS 2.0, RSS 1.0, Atom or JSON Feed document
+func ParseFeed(body []byte) (*Feed, error) {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return parseJSONFeed(trimmed)
+	}
+
+	var root struct {
+		XMLName xml.Name
+	}
+
+	err := newXMLDecoder(trimmed).Decode(&root)
+	if err != nil {
+		return nil, ErrUnknownFormat
+	}
+
+	switch strings.ToLower(root.XMLName.Local) {
+	case "rss", "rdf":
+		return parseRSS(trimmed)
+	case "feed":
+		return parseAtom(trimmed)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+func newXMLDecoder(body []byte) *xml.Decoder {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.CharsetReader = charset.NewReaderLabel
+	// a lot of feeds in the wild are not entirely valid xml (unescaped & and so on)
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	return dec
+}
+
+// xmlLink handles both plain rss links and atom links, rss feeds often include a atom:link to itself in the channel
+type xmlLink struct {
+	XMLName xml.Name
+	Href    string `xml:"href,attr"`
+	Rel     string `xml:"rel,attr"`
+	Type    string `xml:"type,attr"`
+	Value   string `xml:",chardata"`
+}
+
+func pickLink(links []xmlLink) string {
+	for _, v := range links {
+		if v.XMLName.Space == "" || v.XMLName.Space == "http://purl.org/rss/1.0/" {
+			if s := strings.TrimSpace(v.Value); s != "" {
+				return s
+			}
+		}
+	}
+
+	// atom links, prefer the alternate one
+	for _, v := range links {
+		if v.Href != "" && (v.Rel == "" || v.Rel == "alternate") {
+			return v.Href
+		}
+	}
+
+	return ""
+}
+
+type xmlMedia struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+func (m xmlMedia) isImage() bool {
+	return m.URL != "" && (strings.HasPrefix(m.Type, "image/") || m.Medium == "image")
+}
+
+// xmlMediaGroup is a media rss group, the thumbnails and contents can also be directly in the item
+type xmlMediaGroup struct {
+	Thumbnails  []xmlMedia `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	Contents    []xmlMedia `xml:"http://search.yahoo.com/mrss/ content"`
+	Description string     `xml:"http://search.yahoo.com/mrss/ description"`
+}
+
+func (g xmlMediaGroup) image() string {
+	for _, v := range g.Thumbnails {
+		if v.URL != "" {
+			return v.URL
+		}
+	}
+
+	for _, v := range g.Contents {
+		if v.isImage() {
+			return v.URL
+		}
+	}
+
+	return ""
+}
+
+type rssDocument struct {
+	Channel struct {
+		Title string     `xml:"title"`
+		Links []xmlLink  `xml:"link"`
+		Items []*rssItem `xml:"item"`
+	} `xml:"channel"`
+
+	// RSS 1.0 has the items next to the channel
+	Items []*rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string     `xml:"title"`
+	Links       []xmlLink  `xml:"link"`
+	Description string     `xml:"description"`
+	Content     string     `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	GUID        string     `xml:"guid"`
+	About       string     `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	PubDate     string     `xml:"pubDate"`
+	DCDate      string     `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Author      string     `xml:"author"`
+	Creator     string     `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Enclosures  []xmlMedia `xml:"enclosure"`
+
+	MediaThumbnails []xmlMedia    `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaContents   []xmlMedia    `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaGroup      xmlMediaGroup `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+func parseRSS(body []byte) (*Feed, error) {
+	var doc rssDocument
+	err := newXMLDecoder(body).Decode(&doc)
+	if err != nil {
+		return nil, errors.WithMessage(err, "rss")
+	}
+
+	feed := &Feed{
+		Title: strings.TrimSpace(doc.Channel.Title),
+		Link:  pickLink(doc.Channel.Links),
+	}
+
+	items := doc.Channel.Items
+	if len(items) == 0 {
+		items = doc.Items
+	}
+
+	for _, v := range items {
+		item := &FeedItem{
+			GUID:      strings.TrimSpace(v.GUID),
+			Title:     cleanText(v.Title),
+			Link:      pickLink(v.Links),
+			Author:    strings.TrimSpace(v.Author),
+			Published: parseTime(v.PubDate),
+		}
+
+		if item.GUID == "" {
+			item.GUID = strings.TrimSpace(v.About)
+		}
+
+		if item.Author == "" {
+			item.Author = strings.TrimSpace(v.Creator)
+		}
+
+		if item.Published.IsZero() {
+			item.Published = parseTime(v.DCDate)
+		}
+
+		description := v.Description
+		if description == "" {
+			description = v.Content
+		}
+		item.Description = cleanText(description)
+
+		for _, enc := range v.Enclosures {
+			if strings.HasPrefix(enc.Type, "image/") {
+				item.ImageURL = enc.URL
+				break
+			}
+		}
+
+		if item.ImageURL == "" {
+			media := xmlMediaGroup{Thumbnails: v.MediaThumbnails, Contents: v.MediaContents}
+			item.ImageURL = firstNonEmpty(media.image(), v.MediaGroup.image(), findHTMLImage(description), findHTMLImage(v.Content))
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	finishItems(feed)
+	return feed, nil
+}
+
+// atom elements are always namespaced, which avoids conflicts with the media rss elements
+type atomDocument struct {
+	Title   string       `xml:"http://www.w3.org/2005/Atom title"`
+	Links   []xmlLink    `xml:"http://www.w3.org/2005/Atom link"`
+	Entries []*atomEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type atomEntry struct {
+	ID        string    `xml:"http://www.w3.org/2005/Atom id"`
+	Title     string    `xml:"http://www.w3.org/2005/Atom title"`
+	Links     []xmlLink `xml:"http://www.w3.org/2005/Atom link"`
+	Summary   string    `xml:"http://www.w3.org/2005/Atom summary"`
+	Content   string    `xml:"http://www.w3.org/2005/Atom content"`
+	Published string    `xml:"http://www.w3.org/2005/Atom published"`
+	Updated   string    `xml:"http://www.w3.org/2005/Atom updated"`
+	Authors   []struct {
+		Name string `xml:"http://www.w3.org/2005/Atom name"`
+	} `xml:"http://www.w3.org/2005/Atom author"`
+
+	MediaThumbnails []xmlMedia    `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaContents   []xmlMedia    `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaGroup      xmlMediaGroup `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+func parseAtom(body []byte) (*Feed, error) {
+	var doc atomDocument
+	err := newXMLDecoder(body).Decode(&doc)
+	if err != nil {
+		return nil, errors.WithMessage(err, "atom")
+	}
+
+	feed := &Feed{
+		Title: strings.TrimSpace(doc.Title),
+		Link:  pickLink(doc.Links),
+	}
+
+	for _, v := range doc.Entries {
+		item := &FeedItem{
+			GUID:      strings.TrimSpace(v.ID),
+			Title:     cleanText(v.Title),
+			Link:      pickLink(v.Links),
+			Published: parseTime(v.Published),
+		}
+
+		if item.Published.IsZero() {
+			item.Published = parseTime(v.Updated)
+		}
+
+		if len(v.Authors) > 0 {
+			item.Author = strings.TrimSpace(v.Authors[0].Name)
+		}
+
+		description := v.Summary
+		if description == "" {
+			description = v.Content
+		}
+		if description == "" {
+			// youtube puts the video description in the media group
+			description = v.MediaGroup.Description
+		}
+		item.Description = cleanText(description)
+
+		media := xmlMediaGroup{Thumbnails: v.MediaThumbnails, Contents: v.MediaContents}
+		item.ImageURL = firstNonEmpty(media.image(), v.MediaGroup.image(), findHTMLImage(description), findHTMLImage(v.Content))
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	finishItems(feed)
+	return feed, nil
+}
+
+type jsonFeedDocument struct {
+	Version     string `json:"version"`
+	Title       string `json:"title"`
+	HomePageURL string `json:"home_page_url"`
+	Items       []struct {
+		// the spec says this should be a string, but some feeds use numbers
+		ID            json.RawMessage `json:"id"`
+		URL           string          `json:"url"`
+		ExternalURL   string          `json:"external_url"`
+		Title         string          `json:"title"`
+		ContentHTML   string          `json:"content_html"`
+		ContentText   string          `json:"content_text"`
+		Summary       string          `json:"summary"`
+		Image         string          `json:"image"`
+		BannerImage   string          `json:"banner_image"`
+		DatePublished string          `json:"date_published"`
+		DateModified  string          `json:"date_modified"`
+
+		// author is from version 1, authors from 1.1
+		Author  *jsonFeedAuthor   `json:"author"`
+		Authors []*jsonFeedAuthor `json:"authors"`
+	} `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+func parseJSONFeed(body []byte) (*Feed, error) {
+	var doc jsonFeedDocument
+	err := json.Unmarshal(body, &doc)
+	if err != nil {
+		return nil, errors.WithMessage(err, "json feed")
+	}
+
+	if !strings.HasPrefix(doc.Version, "https://jsonfeed.org/version/") {
+		return nil, ErrUnknownFormat
+	}
+
+	feed := &Feed{
+		Title: strings.TrimSpace(doc.Title),
+		Link:  doc.HomePageURL,
+	}
+
+	for _, v := range doc.Items {
+		item := &FeedItem{
+			Title:     cleanText(v.Title),
+			Link:      firstNonEmpty(v.URL, v.ExternalURL),
+			Published: parseTime(v.DatePublished),
+		}
+
+		var strID string
+		if json.Unmarshal(v.ID, &strID) == nil {
+			item.GUID = strID
+		} else {
+			item.GUID = strings.TrimSpace(string(v.ID))
+		}
+
+		if item.Published.IsZero() {
+			item.Published = parseTime(v.DateModified)
+		}
+
+		if v.Author != nil {
+			item.Author = v.Author.Name
+		} else if len(v.Authors) > 0 && v.Authors[0] != nil {
+			item.Author = v.Authors[0].Name
+		}
+
+		item.Description = cleanText(firstNonEmpty(v.Summary, v.ContentText, v.ContentHTML))
+		item.ImageURL = firstNonEmpty(v.Image, v.BannerImage, findHTMLImage(v.ContentHTML))
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	finishItems(feed)
+	return feed, nil
+}
+
+// finishItems falls back to the link or title for items without a GUID, and removes items we can't identify at all
+func finishItems(feed *Feed) {
+	filtered := feed.Items[:0]
+	for _, v := range feed.Items {
+		if v.GUID == "" {
+			v.GUID = v.Link
+		}
+
+		if v.GUID == "" && v.Title != "" {
+			v.GUID = v.Title + "|" + v.Published.UTC().Format(time.RFC3339)
+		}
+
+		if v.GUID == "" {
+			continue
+		}
+
+		filtered = append(filtered, v)
+	}
+
+	feed.Items = filtered
+}
+
+// SortOldestFirst sorts the items by publish date with the oldest first, items without a date
+// are assumed to be in newest first order like most feeds are
+func SortOldestFirst(items []*FeedItem) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Published.IsZero() || items[j].Published.IsZero() {
+			return false
+		}
+
+		return items[i].Published.Before(items[j].Published)
+	})
+}
+
+var timeFormats = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC3339Nano,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"02 Jan 2006 15:04:05 -0700",
+	time.RFC822Z,
+	time.RFC822,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func parseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+
+	for _, f := range timeFormats {
+		if t, err := time.Parse(f, s); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+var (
+	htmlTagRegex   = regexp.MustCompile(`(?s)<[^>]*>`)
+	htmlImageRegex = regexp.MustCompile(`(?i)<img[^>]+src\s*=\s*["']([^"']+)["']`)
+	spaceRegex     = regexp.MustCompile(`[ \t]+`)
+	newlinesRegex  = regexp.MustCompile(`\s*\n\s*(\n\s*)+`)
+	blockTagRegex  = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/h[1-6])[^>]*>`)
+)
+
+// cleanText turns html into plain text
+func cleanText(s string) string {
+	s = blockTagRegex.ReplaceAllString(s, "\n")
+	s = htmlTagRegex.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.Replace(s, "\r", "", -1)
+	s = spaceRegex.ReplaceAllString(s, " ")
+	s = newlinesRegex.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+func findHTMLImage(s string) string {
+	m := htmlImageRegex.FindStringSubmatch(s)
+	if len(m) < 2 {
+		return ""
+	}
+
+	return html.UnescapeString(m[1])
+}
+
+func firstNonEmpty(s ...string) string {
+	for _, v := range s {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
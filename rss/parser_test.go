@@ -0,0 +1,168 @@
+package rss
+
+import (
+	"testing"
+	"time"
+)
+
+const testRSSFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:media="http://search.yahoo.com/mrss/">
+<channel>
+	<title>Patch notes</title>
+	<atom:link href="https://example.com/feed.xml" rel="self" type="application/rss+xml" />
+	<link>https://example.com/</link>
+	<item>
+		<title>Patch 1.2 &amp; hotfix</title>
+		<link>https://example.com/1.2</link>
+		<guid isPermaLink="false">patch-1.2</guid>
+		<pubDate>Tue, 10 Mar 2020 15:04:05 +0000</pubDate>
+		<dc:creator>dev team</dc:creator>
+		<description><![CDATA[<p>Fixed <b>stuff</b></p><img src="https://example.com/1.2.png">]]></description>
+	</item>
+	<item>
+		<title>Patch 1.1</title>
+		<link>https://example.com/1.1</link>
+		<pubDate>Mon, 9 Mar 2020 15:04:05 GMT</pubDate>
+		<media:thumbnail url="https://example.com/1.1.png" />
+	</item>
+</channel>
+</rss>`
+
+const testAtomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+	<title>Changelog</title>
+	<link href="https://example.com/feed.atom" rel="self" />
+	<link href="https://example.com/" />
+	<entry>
+		<id>urn:uuid:1</id>
+		<title>Release v2</title>
+		<link rel="alternate" href="https://example.com/v2" />
+		<updated>2020-03-10T15:04:05Z</updated>
+		<author><name>someone</name></author>
+		<media:group>
+			<media:thumbnail url="https://example.com/v2.png" />
+			<media:description>v2 is out</media:description>
+		</media:group>
+	</entry>
+</feed>`
+
+const testJSONFeed = `{
+	"version": "https://jsonfeed.org/version/1.1",
+	"title": "Blog",
+	"home_page_url": "https://example.com/",
+	"items": [
+		{"id": "1", "url": "https://example.com/1", "title": "First", "content_text": "hello", "date_published": "2020-03-10T15:04:05+01:00", "authors": [{"name": "me"}]},
+		{"id": 2, "url": "https://example.com/2", "content_html": "<p>second</p>"}
+	]
+}`
+
+func TestParseRSS(t *testing.T) {
+	feed, err := ParseFeed([]byte(testRSSFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Patch notes" || feed.Link != "https://example.com/" {
+		t.Errorf("unexpected feed: %q %q", feed.Title, feed.Link)
+	}
+
+	if len(feed.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(feed.Items))
+	}
+
+	first := feed.Items[0]
+	if first.GUID != "patch-1.2" || first.Title != "Patch 1.2 & hotfix" || first.Author != "dev team" {
+		t.Errorf("unexpected first item: %+v", first)
+	}
+
+	if first.Description != "Fixed stuff" {
+		t.Errorf("unexpected description: %q", first.Description)
+	}
+
+	if first.ImageURL != "https://example.com/1.2.png" {
+		t.Errorf("unexpected image: %q", first.ImageURL)
+	}
+
+	if !first.Published.Equal(time.Date(2020, 3, 10, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected published time: %s", first.Published)
+	}
+
+	// no guid, falls back to the link
+	second := feed.Items[1]
+	if second.GUID != "https://example.com/1.1" || second.ImageURL != "https://example.com/1.1.png" || second.Published.IsZero() {
+		t.Errorf("unexpected second item: %+v", second)
+	}
+}
+
+func TestParseAtom(t *testing.T) {
+	feed, err := ParseFeed([]byte(testAtomFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Changelog" || feed.Link != "https://example.com/" {
+		t.Errorf("unexpected feed: %q %q", feed.Title, feed.Link)
+	}
+
+	if len(feed.Items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(feed.Items))
+	}
+
+	item := feed.Items[0]
+	if item.GUID != "urn:uuid:1" || item.Link != "https://example.com/v2" || item.Author != "someone" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+
+	if item.Description != "v2 is out" || item.ImageURL != "https://example.com/v2.png" || item.Published.IsZero() {
+		t.Errorf("unexpected item: %+v", item)
+	}
+}
+
+func TestParseJSONFeed(t *testing.T) {
+	feed, err := ParseFeed([]byte(testJSONFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(feed.Items))
+	}
+
+	if feed.Items[0].GUID != "1" || feed.Items[0].Author != "me" || feed.Items[0].Description != "hello" {
+		t.Errorf("unexpected first item: %+v", feed.Items[0])
+	}
+
+	if feed.Items[1].GUID != "2" || feed.Items[1].Description != "second" {
+		t.Errorf("unexpected second item: %+v", feed.Items[1])
+	}
+}
+
+func TestParseUnknown(t *testing.T) {
+	for _, v := range []string{"", "<html><body>not a feed</body></html>", `{"version": "something else"}`} {
+		_, err := ParseFeed([]byte(v))
+		if err == nil {
+			t.Errorf("expected an error parsing %q", v)
+		}
+	}
+}
+
+func TestSortOldestFirst(t *testing.T) {
+	now := time.Now()
+	items := []*FeedItem{
+		{GUID: "b", Published: now},
+		{GUID: "a", Published: now.Add(-time.Hour)},
+		{GUID: "c", Published: now.Add(time.Hour)},
+	}
+
+	SortOldestFirst(items)
+	if items[0].GUID != "a" || items[1].GUID != "b" || items[2].GUID != "c" {
+		t.Errorf("unexpected order: %s %s %s", items[0].GUID, items[1].GUID, items[2].GUID)
+	}
+
+	// no dates, assume newest first
+	items = []*FeedItem{{GUID: "2"}, {GUID: "1"}}
+	SortOldestFirst(items)
+	if items[0].GUID != "1" {
+		t.Errorf("unexpected order: %s %s", items[0].GUID, items[1].GUID)
+	}
+}
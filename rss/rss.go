@@ -0,0 +1,53 @@
+package rss
+
+//go:generate sqlboiler --no-hooks psql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/config"
+	"github.com/jonas747/yagpdb/common/mqueue"
+	"github.com/jonas747/yagpdb/premium"
+)
+
+var (
+	logger = common.GetPluginLogger(&Plugin{})
+
+	confPollInterval = config.RegisterOption("yagpdb.rss.poll_interval", "How often each RSS feed is checked, in minutes", 10)
+)
+
+const (
+	MaxFeedsNormal  = 10
+	MaxFeedsPremium = 100
+)
+
+type Plugin struct {
+	Stop chan *sync.WaitGroup
+}
+
+func (p *Plugin) PluginInfo() *common.PluginInfo {
+	return &common.PluginInfo{
+		Name:     "RSS",
+		SysName:  "rss",
+		Category: common.PluginCategoryFeeds,
+	}
+}
+
+func RegisterPlugin() {
+	p := &Plugin{}
+
+	common.RegisterPlugin(p)
+	mqueue.RegisterSource("rss", p)
+	common.InitSchemas("rss", DBSchemas...)
+}
+
+// MaxFeedsForContext returns the max number of feeds the guild in the context can have
+func MaxFeedsForContext(ctx context.Context) int {
+	if premium.ContextPremium(ctx) {
+		return MaxFeedsPremium
+	}
+
+	return MaxFeedsNormal
+}
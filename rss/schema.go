@@ -0,0 +1,32 @@
+package rss
+
+var DBSchemas = []string{`
+CREATE TABLE IF NOT EXISTS rss_feeds (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id BIGINT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	channel_id BIGINT NOT NULL,
+	enabled BOOLEAN NOT NULL,
+
+	url TEXT NOT NULL,
+	title TEXT NOT NULL DEFAULT '',
+
+	last_checked_at TIMESTAMP WITH TIME ZONE,
+	last_error TEXT NOT NULL DEFAULT '',
+	consecutive_errors INT NOT NULL DEFAULT 0
+);
+`, `
+CREATE INDEX IF NOT EXISTS rss_feeds_guild_id_idx ON rss_feeds(guild_id);
+`, `
+CREATE INDEX IF NOT EXISTS rss_feeds_last_checked_at_idx ON rss_feeds(last_checked_at) WHERE enabled;
+`, `
+CREATE TABLE IF NOT EXISTS rss_feed_seen_items (
+	feed_id BIGINT NOT NULL REFERENCES rss_feeds(id) ON DELETE CASCADE,
+	guid TEXT NOT NULL,
+	last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	PRIMARY KEY(feed_id, guid)
+);
+`,
+}
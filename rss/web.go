@@ -0,0 +1,201 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/cplogs"
+	"github.com/jonas747/yagpdb/rss/models"
+	"github.com/jonas747/yagpdb/web"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"goji.io"
+	"goji.io/pat"
+)
+
+type Form struct {
+	FeedURL        string `valid:",1,2048"`
+	DiscordChannel int64  `valid:"channel,false"`
+}
+
+type EditForm struct {
+	DiscordChannel int64 `valid:"channel,false"`
+}
+
+var (
+	panelLogKeyAddedFeed   = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "rss_added_feed", FormatString: "Added rss feed %s"})
+	panelLogKeyRemovedFeed = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "rss_removed_feed", FormatString: "Removed rss feed %s"})
+	panelLogKeyUpdatedFeed = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "rss_updated_feed", FormatString: "Updated rss feed %s"})
+)
+
+func (p *Plugin) InitWeb() {
+	web.LoadHTMLTemplate("../../rss/assets/rss.html", "templates/plugins/rss.html")
+	web.AddSidebarItem(web.SidebarCategoryFeeds, &web.SidebarItem{
+		Name: "RSS Feeds",
+		URL:  "rss",
+		Icon: "fas fa-rss",
+	})
+
+	mux := goji.SubMux()
+	web.CPMux.Handle(pat.New("/rss/*"), mux)
+	web.CPMux.Handle(pat.New("/rss"), mux)
+
+	mainGetHandler := web.ControllerHandler(p.HandleRSS, "cp_rss")
+
+	mux.Handle(pat.Get("/"), mainGetHandler)
+	mux.Handle(pat.Get(""), mainGetHandler)
+
+	addHandler := web.ControllerPostHandler(p.HandleNew, mainGetHandler, Form{})
+
+	mux.Handle(pat.Post(""), addHandler)
+	mux.Handle(pat.Post("/"), addHandler)
+	mux.Handle(pat.Post("/:item/update"), web.ControllerPostHandler(BaseEditHandler(p.HandleEdit), mainGetHandler, EditForm{}))
+	mux.Handle(pat.Post("/:item/delete"), web.ControllerPostHandler(BaseEditHandler(p.HandleRemove), mainGetHandler, nil))
+}
+
+func (p *Plugin) HandleRSS(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	ag, templateData := web.GetBaseCPContextData(ctx)
+
+	result, err := models.RssFeeds(models.RssFeedWhere.GuildID.EQ(ag.ID), qm.OrderBy("id asc")).AllG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	templateData["FeedItems"] = result
+	templateData["MaxFeeds"] = MaxFeedsForContext(ctx)
+
+	return templateData, nil
+}
+
+func (p *Plugin) HandleNew(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ctx := r.Context()
+	activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+	currentCount, err := models.RssFeeds(models.RssFeedWhere.GuildID.EQ(activeGuild.ID)).CountG(ctx)
+	if err != nil {
+		return templateData, err
+	}
+
+	maxFeeds := MaxFeedsForContext(ctx)
+	if currentCount >= int64(maxFeeds) {
+		return templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Max %d feeds per server", maxFeeds))), nil
+	}
+
+	form := ctx.Value(common.ContextKeyParsedForm).(*Form)
+
+	feedURL, err := ValidateFeedURL(form.FeedURL)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert(err.Error())), nil
+	}
+
+	// make sure it's actually a feed before adding it
+	fetchCtx, cancel := context.WithTimeout(ctx, time.Second*20)
+	defer cancel()
+
+	parsed, err := FetchFeed(fetchCtx, feedURL)
+	if err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Failed fetching the feed: " + err.Error())), nil
+	}
+
+	m := &models.RssFeed{
+		GuildID:   activeGuild.ID,
+		CreatedAt: time.Now(),
+		ChannelID: form.DiscordChannel,
+		Enabled:   true,
+		URL:       feedURL,
+		Title:     common.CutStringShort(parsed.Title, 100),
+	}
+
+	err = m.InsertG(ctx, boil.Infer())
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyAddedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: feedURL}))
+	}
+	return templateData, err
+}
+
+type ContextKey int
+
+const (
+	ContextKeyFeed ContextKey = iota
+)
+
+func BaseEditHandler(inner web.ControllerHandlerFunc) web.ControllerHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+		ctx := r.Context()
+		activeGuild, templateData := web.GetBaseCPContextData(ctx)
+
+		id, _ := strconv.ParseInt(pat.Param(r, "item"), 10, 64)
+
+		feed, err := models.RssFeeds(models.RssFeedWhere.ID.EQ(id), models.RssFeedWhere.GuildID.EQ(activeGuild.ID)).OneG(ctx)
+		if err != nil {
+			return templateData.AddAlerts(web.ErrorAlert("Failed retrieving that feed item")), err
+		}
+
+		ctx = context.WithValue(ctx, ContextKeyFeed, feed)
+
+		return inner(w, r.WithContext(ctx))
+	}
+}
+
+func (p *Plugin) HandleEdit(w http.ResponseWriter, r *http.Request) (templateData web.TemplateData, err error) {
+	ctx := r.Context()
+	_, templateData = web.GetBaseCPContextData(ctx)
+
+	feed := ctx.Value(ContextKeyFeed).(*models.RssFeed)
+	data := ctx.Value(common.ContextKeyParsedForm).(*EditForm)
+
+	// saving a disabled feed enables it again
+	feed.ChannelID = data.DiscordChannel
+	feed.Enabled = true
+	feed.ConsecutiveErrors = 0
+	feed.LastError = ""
+
+	_, err = feed.UpdateG(ctx, boil.Whitelist("channel_id", "enabled", "consecutive_errors", "last_error"))
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: feed.URL}))
+	}
+	return
+}
+
+func (p *Plugin) HandleRemove(w http.ResponseWriter, r *http.Request) (templateData web.TemplateData, err error) {
+	ctx := r.Context()
+	_, templateData = web.GetBaseCPContextData(ctx)
+
+	feed := ctx.Value(ContextKeyFeed).(*models.RssFeed)
+	_, err = feed.DeleteG(ctx)
+	if err == nil {
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyRemovedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: feed.URL}))
+	}
+	return templateData, err
+}
+
+var _ web.PluginWithServerHomeWidget = (*Plugin)(nil)
+
+func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	templateData["WidgetTitle"] = "RSS feeds"
+	templateData["SettingsPath"] = "/rss"
+
+	numFeeds, err := models.RssFeeds(models.RssFeedWhere.GuildID.EQ(ag.ID)).CountG(r.Context())
+	if err != nil {
+		return templateData, err
+	}
+
+	if numFeeds > 0 {
+		templateData["WidgetEnabled"] = true
+	} else {
+		templateData["WidgetDisabled"] = true
+	}
+
+	const format = `<p>Active RSS feeds: <code>%d</code></p>`
+	templateData["WidgetBody"] = template.HTML(fmt.Sprintf(format, numFeeds))
+
+	return templateData, nil
+}
@@ -0,0 +1,185 @@
+package serverstats
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/serverstats/messagestatscollector"
+)
+
+const (
+	// activity stats older than this are deleted by the background worker
+	activityStatsRetentionDays = 90
+
+	activityStatsMaxDaysNormal  = 7
+	activityStatsMaxDaysPremium = activityStatsRetentionDays
+)
+
+// UserActivity is the number of messages sent by a user over a period
+type UserActivity struct {
+	UserID   int64  `json:"user_id,string"`
+	Name     string `json:"name"`
+	Messages int64  `json:"messages"`
+}
+
+// ChannelActivity is the number of messages and unique users in a channel over a period
+type ChannelActivity struct {
+	ChannelID   int64  `json:"channel_id,string"`
+	Name        string `json:"name"`
+	Messages    int64  `json:"messages"`
+	ActiveUsers int64  `json:"active_users"`
+}
+
+// DailyActiveUsers is the number of unique users that sent a message on a day
+type DailyActiveUsers struct {
+	T     time.Time `json:"t"`
+	Users int64     `json:"users"`
+}
+
+// ActivityStats are the per user and per channel stats, only recorded if enabled in the config
+type ActivityStats struct {
+	Days int `json:"days"`
+
+	TopUsers    []*UserActivity     `json:"top_users"`
+	TopChannels []*ChannelActivity  `json:"top_channels"`
+	DailyActive []*DailyActiveUsers `json:"daily_active"`
+
+	// number of messages by weekday (0 being sunday) and hour of the day in UTC
+	Heatmap [7][24]int64 `json:"heatmap"`
+}
+
+// ClampActivityDays returns the number of days of activity stats that can be viewed at once
+func ClampActivityDays(days int, premium bool) int {
+	max := activityStatsMaxDaysNormal
+	if premium {
+		max = activityStatsMaxDaysPremium
+	}
+
+	if days <= 0 || days > max {
+		return max
+	}
+
+	return days
+}
+
+// RetrieveActivityStats returns the activity stats over the last number of days, including today,
+// with at most limit users and channels
+func RetrieveActivityStats(ctx context.Context, guildID int64, now time.Time, days int, limit int) (*ActivityStats, error) {
+	from := messagestatscollector.RoundDay(now).AddDate(0, 0, -(days - 1))
+
+	result := &ActivityStats{
+		Days:        days,
+		TopUsers:    make([]*UserActivity, 0, limit),
+		TopChannels: make([]*ChannelActivity, 0, limit),
+		DailyActive: make([]*DailyActiveUsers, 0, days),
+	}
+
+	const qUsers = `SELECT user_id, SUM(count) AS messages
+	FROM server_stats_daily_user_activity
+	WHERE guild_id = $1 AND t >= $2
+	GROUP BY user_id
+	ORDER BY messages DESC
+	LIMIT $3;`
+
+	rows, err := common.PQ.QueryContext(ctx, qUsers, guildID, from, limit)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	for rows.Next() {
+		u := &UserActivity{}
+		err = rows.Scan(&u.UserID, &u.Messages)
+		if err != nil {
+			rows.Close()
+			return nil, errors.WithStackIf(err)
+		}
+
+		result.TopUsers = append(result.TopUsers, u)
+	}
+	rows.Close()
+
+	const qChannels = `SELECT channel_id, SUM(count) AS messages, COUNT(DISTINCT user_id)
+	FROM server_stats_daily_user_activity
+	WHERE guild_id = $1 AND t >= $2
+	GROUP BY channel_id
+	ORDER BY messages DESC
+	LIMIT $3;`
+
+	rows, err = common.PQ.QueryContext(ctx, qChannels, guildID, from, limit)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	for rows.Next() {
+		c := &ChannelActivity{}
+		err = rows.Scan(&c.ChannelID, &c.Messages, &c.ActiveUsers)
+		if err != nil {
+			rows.Close()
+			return nil, errors.WithStackIf(err)
+		}
+
+		result.TopChannels = append(result.TopChannels, c)
+	}
+	rows.Close()
+
+	const qDaily = `SELECT t, COUNT(DISTINCT user_id)
+	FROM server_stats_daily_user_activity
+	WHERE guild_id = $1 AND t >= $2
+	GROUP BY t
+	ORDER BY t ASC;`
+
+	rows, err = common.PQ.QueryContext(ctx, qDaily, guildID, from)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+
+	for rows.Next() {
+		d := &DailyActiveUsers{}
+		err = rows.Scan(&d.T, &d.Users)
+		if err != nil {
+			rows.Close()
+			return nil, errors.WithStackIf(err)
+		}
+
+		result.DailyActive = append(result.DailyActive, d)
+	}
+	rows.Close()
+
+	const qHours = `SELECT t, count
+	FROM server_stats_hourly_activity
+	WHERE guild_id = $1 AND t >= $2;`
+
+	rows, err = common.PQ.QueryContext(ctx, qHours, guildID, from)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t time.Time
+		var count int64
+		err = rows.Scan(&t, &count)
+		if err != nil {
+			return nil, errors.WithStackIf(err)
+		}
+
+		t = t.UTC()
+		result.Heatmap[t.Weekday()][t.Hour()] += count
+	}
+
+	return result, errors.WithStackIf(rows.Err())
+}
+
+func cleanupOldActivityStats(now time.Time) error {
+	t := messagestatscollector.RoundDay(now).AddDate(0, 0, -activityStatsRetentionDays)
+
+	_, err := common.PQ.Exec("DELETE FROM server_stats_daily_user_activity WHERE t < $1;", t)
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	_, err = common.PQ.Exec("DELETE FROM server_stats_hourly_activity WHERE t < $1;", t)
+	return errors.WithStackIf(err)
+}
@@ -0,0 +1,84 @@
+package serverstats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonas747/yagpdb/common/testutils"
+)
+
+func TestClampActivityDays(t *testing.T) {
+	cases := []struct {
+		days     int
+		premium  bool
+		expected int
+	}{
+		{0, false, activityStatsMaxDaysNormal},
+		{3, false, 3},
+		{30, false, activityStatsMaxDaysNormal},
+		{30, true, 30},
+		{1000, true, activityStatsMaxDaysPremium},
+	}
+
+	for _, c := range cases {
+		if got := ClampActivityDays(c.days, c.premium); got != c.expected {
+			t.Errorf("ClampActivityDays(%d, %t): got %d, expected %d", c.days, c.premium, got, c.expected)
+		}
+	}
+}
+
+func TestRetrieveActivityStats(t *testing.T) {
+	defer testutils.ClearTables(db, "server_stats_daily_user_activity", "server_stats_hourly_activity")
+
+	// a wednesday
+	now := time.Date(2020, 5, 13, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2020, 5, 13, 0, 0, 0, 0, time.UTC)
+
+	insertActivityRow(1, today, 10, 100, 5)
+	insertActivityRow(1, today, 10, 101, 20)
+	insertActivityRow(1, today, 11, 100, 3)
+	insertActivityRow(1, today.AddDate(0, 0, -1), 11, 100, 4)
+	insertActivityRow(1, today.AddDate(0, 0, -7), 11, 100, 1000) // out of range
+	insertActivityRow(2, today, 10, 100, 1000)                   // other guild
+
+	insertHourlyActivityRow(1, now.Truncate(time.Hour), 25)
+	insertHourlyActivityRow(1, now.Truncate(time.Hour).AddDate(0, 0, -1), 4)
+
+	stats, err := RetrieveActivityStats(context.Background(), 1, now, 7, 10)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if len(stats.TopUsers) != 2 || stats.TopUsers[0].UserID != 101 || stats.TopUsers[1].Messages != 12 {
+		t.Errorf("unexpected top users: %+v", stats.TopUsers)
+	}
+
+	if len(stats.TopChannels) != 2 || stats.TopChannels[0].ChannelID != 10 || stats.TopChannels[0].ActiveUsers != 2 {
+		t.Errorf("unexpected top channels: %+v", stats.TopChannels)
+	}
+
+	if len(stats.DailyActive) != 2 || stats.DailyActive[1].Users != 2 {
+		t.Errorf("unexpected daily active users: %+v", stats.DailyActive)
+	}
+
+	if stats.Heatmap[time.Wednesday][15] != 25 || stats.Heatmap[time.Tuesday][15] != 4 {
+		t.Errorf("unexpected heatmap: %v", stats.Heatmap)
+	}
+}
+
+func insertActivityRow(guildID int64, t time.Time, channelID, userID int64, count int) {
+	const q = `INSERT INTO server_stats_daily_user_activity (guild_id, t, channel_id, user_id, count) VALUES ($1, $2, $3, $4, $5)`
+	_, err := db.Exec(q, guildID, t, channelID, userID, count)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func insertHourlyActivityRow(guildID int64, t time.Time, count int) {
+	const q = `INSERT INTO server_stats_hourly_activity (guild_id, t, count) VALUES ($1, $2, $3)`
+	_, err := db.Exec(q, guildID, t, count)
+	if err != nil {
+		panic(err)
+	}
+}
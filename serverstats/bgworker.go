@@ -100,6 +100,10 @@ func (c *Compressor) runLoopLegacy(p *Plugin) {
 			logger.Info("Cleaning up server stats")
 			started := time.Now()
 			p.cleanupOldStats(time.Now().Add(time.Hour * -30))
+			err = cleanupOldActivityStats(time.Now())
+			if err != nil {
+				logger.WithError(err).Error("failed cleaning up activity stats")
+			}
 			logger.Infof("Took %s to ckean up stats", time.Since(started))
 		}
 	}
@@ -0,0 +1,230 @@
+package serverstats
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/yagpdb/common"
+)
+
+// ExportRow is implemented by the rows returned from the export endpoints
+type ExportRow interface {
+	CSVRecord() []string
+}
+
+// ExportKind describes a set of stats that can be exported
+type ExportKind struct {
+	Name string
+
+	// the max number of days that can be exported at once, and the default if no range is provided
+	MaxDays     int
+	DefaultDays int
+
+	CSVHeader []string
+	Fetch     func(ctx context.Context, guildID int64, from, to time.Time) ([]ExportRow, error)
+}
+
+var (
+	ExportKindDaily = &ExportKind{
+		Name:        "daily",
+		MaxDays:     366,
+		DefaultDays: 30,
+		CSVHeader:   []string{"date", "num_messages", "num_members", "max_online", "joins", "leaves", "max_voice"},
+		Fetch:       RetrieveDailyExport,
+	}
+
+	ExportKindHourlyMessages = &ExportKind{
+		Name:        "hourly_messages",
+		MaxDays:     31,
+		DefaultDays: 7,
+		CSVHeader:   []string{"t", "channel_id", "count"},
+		Fetch:       RetrieveHourlyMessagesExport,
+	}
+
+	ExportKindHourlyMisc = &ExportKind{
+		Name:        "hourly_misc",
+		MaxDays:     31,
+		DefaultDays: 7,
+		CSVHeader:   []string{"t", "num_members", "max_online", "joins", "leaves", "max_voice"},
+		Fetch:       RetrieveHourlyMiscExport,
+	}
+
+	ExportKinds = []*ExportKind{ExportKindDaily, ExportKindHourlyMessages, ExportKindHourlyMisc}
+)
+
+// non premium servers can only export the last week of stats, same as what's shown in the charts
+const exportMaxDaysNonPremium = 7
+
+// DailyExportRow is a single day from server_stats_periods_compressed
+type DailyExportRow struct {
+	Date        string `json:"date"`
+	NumMessages int    `json:"num_messages"`
+	NumMembers  int64  `json:"num_members"`
+	MaxOnline   int64  `json:"max_online"`
+	Joins       int    `json:"joins"`
+	Leaves      int    `json:"leaves"`
+	MaxVoice    int    `json:"max_voice"`
+}
+
+func (d *DailyExportRow) CSVRecord() []string {
+	return []string{d.Date, strconv.Itoa(d.NumMessages), strconv.FormatInt(d.NumMembers, 10), strconv.FormatInt(d.MaxOnline, 10),
+		strconv.Itoa(d.Joins), strconv.Itoa(d.Leaves), strconv.Itoa(d.MaxVoice)}
+}
+
+// RetrieveDailyExport returns the compressed daily stats between from and to, to being exclusive
+func RetrieveDailyExport(ctx context.Context, guildID int64, from, to time.Time) ([]ExportRow, error) {
+	const q = `SELECT t, num_messages, num_members, max_online, joins, leaves, max_voice
+	FROM server_stats_periods_compressed
+	WHERE guild_id = $1 AND t >= $2 AND t < $3
+	ORDER BY t ASC;`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, from, to)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+	defer rows.Close()
+
+	result := make([]ExportRow, 0)
+	for rows.Next() {
+		var t time.Time
+		row := &DailyExportRow{}
+
+		err = rows.Scan(&t, &row.NumMessages, &row.NumMembers, &row.MaxOnline, &row.Joins, &row.Leaves, &row.MaxVoice)
+		if err != nil {
+			return nil, errors.WithStackIf(err)
+		}
+
+		row.Date = t.Format("2006-01-02")
+		result = append(result, row)
+	}
+
+	return result, errors.WithStackIf(rows.Err())
+}
+
+// HourlyMessagesExportRow is the number of messages in a channel during an hour
+type HourlyMessagesExportRow struct {
+	T         time.Time `json:"t"`
+	ChannelID int64     `json:"channel_id,string"`
+	Count     int64     `json:"count"`
+}
+
+func (h *HourlyMessagesExportRow) CSVRecord() []string {
+	return []string{h.T.UTC().Format(time.RFC3339), strconv.FormatInt(h.ChannelID, 10), strconv.FormatInt(h.Count, 10)}
+}
+
+// RetrieveHourlyMessagesExport returns the hourly message stats between from and to, to being exclusive
+func RetrieveHourlyMessagesExport(ctx context.Context, guildID int64, from, to time.Time) ([]ExportRow, error) {
+	const q = `SELECT t, COALESCE(channel_id, 0), COALESCE(count, 0)
+	FROM server_stats_hourly_periods_messages
+	WHERE guild_id = $1 AND t >= $2 AND t < $3
+	ORDER BY t ASC, channel_id ASC;`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, from, to)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+	defer rows.Close()
+
+	result := make([]ExportRow, 0)
+	for rows.Next() {
+		row := &HourlyMessagesExportRow{}
+		err = rows.Scan(&row.T, &row.ChannelID, &row.Count)
+		if err != nil {
+			return nil, errors.WithStackIf(err)
+		}
+
+		result = append(result, row)
+	}
+
+	return result, errors.WithStackIf(rows.Err())
+}
+
+// HourlyMiscExportRow is the member stats during an hour
+type HourlyMiscExportRow struct {
+	T          time.Time `json:"t"`
+	NumMembers int64     `json:"num_members"`
+	MaxOnline  int64     `json:"max_online"`
+	Joins      int       `json:"joins"`
+	Leaves     int       `json:"leaves"`
+	MaxVoice   int       `json:"max_voice"`
+}
+
+func (h *HourlyMiscExportRow) CSVRecord() []string {
+	return []string{h.T.UTC().Format(time.RFC3339), strconv.FormatInt(h.NumMembers, 10), strconv.FormatInt(h.MaxOnline, 10),
+		strconv.Itoa(h.Joins), strconv.Itoa(h.Leaves), strconv.Itoa(h.MaxVoice)}
+}
+
+// RetrieveHourlyMiscExport returns the hourly member stats between from and to, to being exclusive
+func RetrieveHourlyMiscExport(ctx context.Context, guildID int64, from, to time.Time) ([]ExportRow, error) {
+	const q = `SELECT t, num_members, max_online, joins, leaves, max_voice
+	FROM server_stats_hourly_periods_misc
+	WHERE guild_id = $1 AND t >= $2 AND t < $3
+	ORDER BY t ASC;`
+
+	rows, err := common.PQ.QueryContext(ctx, q, guildID, from, to)
+	if err != nil {
+		return nil, errors.WithStackIf(err)
+	}
+	defer rows.Close()
+
+	result := make([]ExportRow, 0)
+	for rows.Next() {
+		row := &HourlyMiscExportRow{}
+		err = rows.Scan(&row.T, &row.NumMembers, &row.MaxOnline, &row.Joins, &row.Leaves, &row.MaxVoice)
+		if err != nil {
+			return nil, errors.WithStackIf(err)
+		}
+
+		result = append(result, row)
+	}
+
+	return result, errors.WithStackIf(rows.Err())
+}
+
+// ParseExportRange parses the from and to dates (YYYY-MM-DD, both inclusive) and returns the start of the from day
+// and the start of the day after to, non premium servers are limited to the last exportMaxDaysNonPremium days
+func ParseExportRange(kind *ExportKind, fromStr, toStr string, now time.Time, isPremium bool) (from time.Time, to time.Time, err error) {
+	today := now.UTC().Truncate(time.Hour * 24)
+
+	maxDays := kind.MaxDays
+	defaultDays := kind.DefaultDays
+	if !isPremium && maxDays > exportMaxDaysNonPremium {
+		maxDays = exportMaxDaysNonPremium
+	}
+	if defaultDays > maxDays {
+		defaultDays = maxDays
+	}
+
+	to = today
+	if toStr != "" {
+		to, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return from, to, errors.New("invalid 'to' date, expected YYYY-MM-DD")
+		}
+	}
+
+	from = to.AddDate(0, 0, -(defaultDays - 1))
+	if fromStr != "" {
+		from, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return from, to, errors.New("invalid 'from' date, expected YYYY-MM-DD")
+		}
+	}
+
+	to = to.AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return from, to, errors.New("'from' has to be before 'to'")
+	}
+
+	if to.Sub(from) > time.Hour*24*time.Duration(maxDays) {
+		return from, to, errors.Errorf("can't export more than %d days of %s stats at once", maxDays, kind.Name)
+	}
+
+	if !isPremium && from.Before(today.AddDate(0, 0, -(exportMaxDaysNonPremium-1))) {
+		return from, to, errors.Errorf("only the last %d days of stats can be exported on non premium servers", exportMaxDaysNonPremium)
+	}
+
+	return from, to, nil
+}
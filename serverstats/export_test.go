@@ -0,0 +1,80 @@
+package serverstats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonas747/yagpdb/common/testutils"
+)
+
+func TestParseExportRange(t *testing.T) {
+	now := time.Date(2020, 5, 10, 15, 30, 0, 0, time.UTC)
+
+	from, to, err := ParseExportRange(ExportKindDaily, "", "", now, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !from.Equal(time.Date(2020, 4, 11, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2020, 5, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected default range: %s - %s", from, to)
+	}
+
+	from, to, err = ParseExportRange(ExportKindHourlyMisc, "2020-05-01", "2020-05-01", now, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if to.Sub(from) != time.Hour*24 {
+		t.Errorf("expected a single day, got %s - %s", from, to)
+	}
+
+	if _, _, err = ParseExportRange(ExportKindHourlyMisc, "2020-01-01", "2020-05-01", now, true); err == nil {
+		t.Error("expected an error for a range above the max days")
+	}
+
+	if _, _, err = ParseExportRange(ExportKindDaily, "2020-05-02", "2020-05-01", now, true); err == nil {
+		t.Error("expected an error for from being after to")
+	}
+
+	if _, _, err = ParseExportRange(ExportKindDaily, "yesterday", "", now, true); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+
+	from, to, err = ParseExportRange(ExportKindDaily, "", "", now, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if to.Sub(from) != time.Hour*24*exportMaxDaysNonPremium {
+		t.Errorf("expected the default range of non premium servers to be capped, got %s - %s", from, to)
+	}
+
+	if _, _, err = ParseExportRange(ExportKindDaily, "2020-04-01", "2020-04-03", now, false); err == nil {
+		t.Error("expected an error for a non premium server exporting older stats")
+	}
+}
+
+func TestRetrieveHourlyMessagesExport(t *testing.T) {
+	tim := time.Now()
+
+	defer testutils.ClearTables(db, "server_stats_hourly_periods_messages")
+
+	InsertMessageRow(1, 2, tim, 5)
+	InsertMessageRow(1, 3, tim, 7)
+	InsertMessageRow(1, 2, tim.Add(time.Hour*-48), 10) // out of range
+	InsertMessageRow(2, 2, tim, 10)                    // other guild
+
+	rows, err := RetrieveHourlyMessagesExport(context.Background(), 1, tim.Add(-time.Hour*2), tim.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	if rows[0].(*HourlyMessagesExportRow).ChannelID != 2 || rows[1].(*HourlyMessagesExportRow).Count != 7 {
+		t.Errorf("unexpected rows: %#v %#v", rows[0], rows[1])
+	}
+}
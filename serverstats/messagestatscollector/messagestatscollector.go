@@ -1,9 +1,13 @@
 package messagestatscollector
 
 import (
+	"database/sql"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"emperror.dev/errors"
 	"github.com/jonas747/discordgo"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/mediocregopher/radix/v3"
@@ -14,9 +18,15 @@ import (
 type Collector struct {
 	MsgEvtChan chan *discordgo.Message
 
+	// ActivityEvtChan receives the messages from guilds with activity stats enabled,
+	// these are recorded per user in addition to the per channel counts
+	ActivityEvtChan chan *discordgo.Message
+
 	interval time.Duration
 
 	channels map[int64]*entry
+	activity map[activityKey]int64
+	hours    map[hourKey]int64
 	// buf      []*discordgo.Message
 	// channels []int64
 	l *logrus.Entry
@@ -28,13 +38,28 @@ type entry struct {
 	Count     int64
 }
 
+type activityKey struct {
+	GuildID   int64
+	ChannelID int64
+	UserID    int64
+	Day       time.Time
+}
+
+type hourKey struct {
+	GuildID int64
+	T       time.Time
+}
+
 // NewCollector creates a new Collector
 func NewCollector(l *logrus.Entry, updateInterval time.Duration) *Collector {
 	col := &Collector{
-		MsgEvtChan: make(chan *discordgo.Message, 10000),
-		interval:   updateInterval,
-		l:          l,
-		channels:   make(map[int64]*entry),
+		MsgEvtChan:      make(chan *discordgo.Message, 10000),
+		ActivityEvtChan: make(chan *discordgo.Message, 10000),
+		interval:        updateInterval,
+		l:               l,
+		channels:        make(map[int64]*entry),
+		activity:        make(map[activityKey]int64),
+		hours:           make(map[hourKey]int64),
 	}
 
 	go col.run()
@@ -50,8 +75,15 @@ func (c *Collector) run() {
 		select {
 		case msg := <-c.MsgEvtChan:
 			c.handleIncMessage(msg)
+		case msg := <-c.ActivityEvtChan:
+			c.handleIncActivity(msg)
 		case <-ticker.C:
-			err := c.flush()
+			err := c.flushActivity()
+			if err != nil {
+				c.l.Errorf("failed updating activity serverstats: %+v", err)
+			}
+
+			err = c.flush()
 			if err != nil {
 				c.l.Errorf("failed updating temp serverstats: %+v", err)
 			}
@@ -72,6 +104,19 @@ func (c *Collector) handleIncMessage(msg *discordgo.Message) {
 	}
 }
 
+func (c *Collector) handleIncActivity(msg *discordgo.Message) {
+	t := time.Now().UTC()
+
+	c.activity[activityKey{
+		GuildID:   msg.GuildID,
+		ChannelID: msg.ChannelID,
+		UserID:    msg.Author.ID,
+		Day:       RoundDay(t),
+	}]++
+
+	c.hours[hourKey{GuildID: msg.GuildID, T: RoundHour(t)}]++
+}
+
 func KeyMessageStats(guildID int64, year, day int) string {
 	return "serverstats_message_stats:" + strconv.FormatInt(guildID, 10) + ":" + strconv.Itoa(year) + ":" + strconv.Itoa(day)
 }
@@ -114,6 +159,111 @@ func (c *Collector) flush() error {
 	return nil
 }
 
+// max rows per insert statement when flushing activity, postgres allows max 65535 parameters per statement
+const maxActivityRowsPerInsert = 1000
+
+// flushActivity writes the per user and per hour activity to postgres
+func (c *Collector) flushActivity() error {
+	if len(c.activity) < 1 && len(c.hours) < 1 {
+		return nil
+	}
+
+	const qActivity = `INSERT INTO server_stats_daily_user_activity (guild_id, t, channel_id, user_id, count)
+	VALUES %s
+	ON CONFLICT (guild_id, t, channel_id, user_id) DO UPDATE SET
+	count = server_stats_daily_user_activity.count + EXCLUDED.count;`
+
+	const qHours = `INSERT INTO server_stats_hourly_activity (guild_id, t, count)
+	VALUES %s
+	ON CONFLICT (guild_id, t) DO UPDATE SET
+	count = server_stats_hourly_activity.count + EXCLUDED.count;`
+
+	activityRows := make([][]interface{}, 0, len(c.activity))
+	for k, v := range c.activity {
+		activityRows = append(activityRows, []interface{}{k.GuildID, k.Day, k.ChannelID, k.UserID, v})
+	}
+
+	hourRows := make([][]interface{}, 0, len(c.hours))
+	for k, v := range c.hours {
+		hourRows = append(hourRows, []interface{}{k.GuildID, k.T, v})
+	}
+
+	tx, err := common.PQ.Begin()
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	err = execMultiInsert(tx, qActivity, activityRows)
+	if err == nil {
+		err = execMultiInsert(tx, qHours, hourRows)
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	c.activity = make(map[activityKey]int64)
+	c.hours = make(map[hourKey]int64)
+	return nil
+}
+
+// execMultiInsert runs query with all the rows in as few statements as possible, query has a %s where the values go
+func execMultiInsert(tx *sql.Tx, query string, rows [][]interface{}) error {
+	for len(rows) > 0 {
+		n := len(rows)
+		if n > maxActivityRowsPerInsert {
+			n = maxActivityRowsPerInsert
+		}
+
+		values, args := multiInsertValues(rows[:n])
+		_, err := tx.Exec(fmt.Sprintf(query, values), args...)
+		if err != nil {
+			return errors.WithStackIf(err)
+		}
+
+		rows = rows[n:]
+	}
+
+	return nil
+}
+
+// multiInsertValues returns the placeholders for a multi row VALUES list, e.g. "($1, $2), ($3, $4)", and the flattened args
+func multiInsertValues(rows [][]interface{}) (string, []interface{}) {
+	var sb strings.Builder
+	args := make([]interface{}, 0, len(rows)*len(rows[0]))
+
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString("(")
+		for j, v := range row {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+
+			args = append(args, v)
+			sb.WriteString("$" + strconv.Itoa(len(args)))
+		}
+		sb.WriteString(")")
+	}
+
+	return sb.String(), args
+}
+
+// RoundDay rounds a time.Time down to the start of the day
+func RoundDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
 func RoundHour(t time.Time) time.Time {
 	t = t.UTC()
 	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
@@ -0,0 +1,17 @@
+package messagestatscollector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMultiInsertValues(t *testing.T) {
+	values, args := multiInsertValues([][]interface{}{{1, "a"}, {2, "b"}})
+	if values != "($1, $2), ($3, $4)" {
+		t.Errorf("unexpected values: %s", values)
+	}
+
+	if !reflect.DeepEqual(args, []interface{}{1, "a", 2, "b"}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
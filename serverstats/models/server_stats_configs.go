@@ -29,6 +29,8 @@ type ServerStatsConfig struct {
 	UpdatedAt      null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
 	Public         null.Bool   `boil:"public" json:"public,omitempty" toml:"public" yaml:"public,omitempty"`
 	IgnoreChannels null.String `boil:"ignore_channels" json:"ignore_channels,omitempty" toml:"ignore_channels" yaml:"ignore_channels,omitempty"`
+	APIToken       string      `boil:"api_token" json:"api_token" toml:"api_token" yaml:"api_token"`
+	ActivityStats  bool        `boil:"activity_stats" json:"activity_stats" toml:"activity_stats" yaml:"activity_stats"`
 
 	R *serverStatsConfigR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L serverStatsConfigL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -40,12 +42,16 @@ var ServerStatsConfigColumns = struct {
 	UpdatedAt      string
 	Public         string
 	IgnoreChannels string
+	APIToken       string
+	ActivityStats  string
 }{
 	GuildID:        "guild_id",
 	CreatedAt:      "created_at",
 	UpdatedAt:      "updated_at",
 	Public:         "public",
 	IgnoreChannels: "ignore_channels",
+	APIToken:       "api_token",
+	ActivityStats:  "activity_stats",
 }
 
 // Generated where
@@ -128,18 +134,40 @@ func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 var ServerStatsConfigWhere = struct {
 	GuildID        whereHelperint64
 	CreatedAt      whereHelpernull_Time
 	UpdatedAt      whereHelpernull_Time
 	Public         whereHelpernull_Bool
 	IgnoreChannels whereHelpernull_String
+	APIToken       whereHelperstring
+	ActivityStats  whereHelperbool
 }{
 	GuildID:        whereHelperint64{field: "\"server_stats_configs\".\"guild_id\""},
 	CreatedAt:      whereHelpernull_Time{field: "\"server_stats_configs\".\"created_at\""},
 	UpdatedAt:      whereHelpernull_Time{field: "\"server_stats_configs\".\"updated_at\""},
 	Public:         whereHelpernull_Bool{field: "\"server_stats_configs\".\"public\""},
 	IgnoreChannels: whereHelpernull_String{field: "\"server_stats_configs\".\"ignore_channels\""},
+	APIToken:       whereHelperstring{field: "\"server_stats_configs\".\"api_token\""},
+	ActivityStats:  whereHelperbool{field: "\"server_stats_configs\".\"activity_stats\""},
 }
 
 // ServerStatsConfigRels is where relationship names are stored.
@@ -159,9 +187,9 @@ func (*serverStatsConfigR) NewStruct() *serverStatsConfigR {
 type serverStatsConfigL struct{}
 
 var (
-	serverStatsConfigAllColumns            = []string{"guild_id", "created_at", "updated_at", "public", "ignore_channels"}
+	serverStatsConfigAllColumns            = []string{"guild_id", "created_at", "updated_at", "public", "ignore_channels", "api_token", "activity_stats"}
 	serverStatsConfigColumnsWithoutDefault = []string{"created_at", "updated_at", "public", "ignore_channels"}
-	serverStatsConfigColumnsWithDefault    = []string{"guild_id"}
+	serverStatsConfigColumnsWithDefault    = []string{"guild_id", "api_token", "activity_stats"}
 	serverStatsConfigPrimaryKeyColumns     = []string{"guild_id"}
 )
 
@@ -13,6 +13,7 @@ import (
 	"github.com/jonas747/yagpdb/bot/eventsystem"
 	"github.com/jonas747/yagpdb/commands"
 	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/premium"
 	"github.com/jonas747/yagpdb/serverstats/messagestatscollector"
 	"github.com/jonas747/yagpdb/web"
 	"github.com/mediocregopher/radix/v3"
@@ -50,6 +51,11 @@ func (p *Plugin) AddCommands() {
 		Cooldown:      5,
 		Name:          "Stats",
 		Description:   "Shows server stats (if public stats are enabled)",
+		LongDescription: "If activity stats are enabled in the control panel the top chatters and most active channels over the last `Days` are also shown. " +
+			"Up to 7 days can be shown, or " + strconv.Itoa(activityStatsMaxDaysPremium) + " on premium servers.",
+		Arguments: []*dcmd.ArgDef{
+			{Name: "Days", Type: &dcmd.IntArg{Min: 1, Max: activityStatsMaxDaysPremium}, Default: activityStatsMaxDaysNormal},
+		},
 		RunFunc: func(data *dcmd.Data) (interface{}, error) {
 			config, err := GetConfig(data.Context(), data.GuildData.GS.ID)
 			if err != nil {
@@ -82,11 +88,74 @@ func (p *Plugin) AddCommands() {
 				},
 			}
 
+			if config.ActivityStats {
+				err = addActivityStatsFields(data, embed)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			return embed, nil
 		},
 	})
 }
 
+func addActivityStatsFields(data *dcmd.Data, embed *discordgo.MessageEmbed) error {
+	isPremium, err := premium.IsGuildPremium(data.GuildData.GS.ID)
+	if err != nil {
+		return errors.WithStackIf(err)
+	}
+
+	days := ClampActivityDays(data.Args[0].Int(), isPremium)
+
+	activity, err := RetrieveActivityStats(data.Context(), data.GuildData.GS.ID, time.Now(), days, 5)
+	if err != nil {
+		return errors.WithMessage(err, "retrieveactivitystats")
+	}
+
+	userIDs := make([]int64, 0, len(activity.TopUsers))
+	for _, v := range activity.TopUsers {
+		userIDs = append(userIDs, v.UserID)
+	}
+
+	members, err := bot.GetMembers(data.GuildData.GS.ID, userIDs...)
+	if err != nil {
+		logger.WithError(err).WithField("guild", data.GuildData.GS.ID).Error("failed fetching top chatters")
+	}
+
+	topUsers := ""
+	for i, v := range activity.TopUsers {
+		name := strconv.FormatInt(v.UserID, 10)
+		for _, ms := range members {
+			if ms.User.ID == v.UserID {
+				name = ms.User.Username
+				break
+			}
+		}
+
+		topUsers += fmt.Sprintf("`#%d` %s: %d messages\n", i+1, name, v.Messages)
+	}
+
+	topChannels := ""
+	for i, v := range activity.TopChannels {
+		topChannels += fmt.Sprintf("`#%d` <#%d>: %d messages, %d users\n", i+1, v.ChannelID, v.Messages, v.ActiveUsers)
+	}
+
+	if topUsers == "" {
+		topUsers = "No activity recorded yet"
+	}
+	if topChannels == "" {
+		topChannels = "No activity recorded yet"
+	}
+
+	embed.Fields = append(embed.Fields,
+		&discordgo.MessageEmbedField{Name: fmt.Sprintf("Top chatters %dd", days), Value: topUsers},
+		&discordgo.MessageEmbedField{Name: fmt.Sprintf("Most active channels %dd", days), Value: topChannels},
+	)
+
+	return nil
+}
+
 func handleUpdateMemberStats(evt *eventsystem.EventData) {
 	select {
 	case memberSatatsUpdater.incoming <- evt:
@@ -116,6 +185,14 @@ func HandleMessageCreate(evt *eventsystem.EventData) (retry bool, err error) {
 	}
 
 	msgStatsCollector.MsgEvtChan <- m.Message
+	if config.ActivityStats {
+		select {
+		case msgStatsCollector.ActivityEvtChan <- m.Message:
+		default:
+			// the collector is falling behind, losing some activity stats is better than stalling the event handlers
+		}
+	}
+
 	return false, nil
 }
 
@@ -2,6 +2,11 @@ package serverstats
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"net/http"
@@ -11,6 +16,7 @@ import (
 	"time"
 
 	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/bot/botrest"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/cplogs"
 	"github.com/jonas747/yagpdb/common/pubsub"
@@ -28,9 +34,11 @@ var WebStatsCache = rcache.New(cacheChartFetcher, time.Minute)
 var WebConfigCache = rcache.NewInt(cacheConfigFetcher, time.Minute)
 
 var panelLogKey = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "serverstats_settings_updated", FormatString: "Updated serverstats settings"})
+var panelLogKeyAPIToken = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "serverstats_api_token_reset", FormatString: "Reset serverstats api token"})
 
 type FormData struct {
 	Public         bool
+	ActivityStats  bool
 	IgnoreChannels []int64 `valid:"channel,false"`
 }
 
@@ -54,11 +62,21 @@ func (p *Plugin) InitWeb() {
 	statsCPMux.Handle(pat.Post("/settings"), web.ControllerPostHandler(HandleSaveStatsSettings, cpGetHandler, FormData{}))
 	statsCPMux.Handle(pat.Get("/daily_json"), web.APIHandler(publicHandlerJson(HandleStatsJson, false)))
 	statsCPMux.Handle(pat.Get("/charts"), web.APIHandler(publicHandlerJson(HandleStatsCharts, false)))
+	statsCPMux.Handle(pat.Get("/activity"), web.APIHandler(publicHandlerJson(HandleActivityStats, false)))
+	statsCPMux.Handle(pat.Post("/api_token"), web.ControllerPostHandler(HandleResetAPIToken, cpGetHandler, nil))
 
 	// Public
 	web.ServerPublicMux.Handle(pat.Get("/stats"), web.ControllerHandler(publicHandler(HandleStatsHtml, true), "cp_serverstats"))
 	web.ServerPublicMux.Handle(pat.Get("/stats/daily_json"), web.APIHandler(publicHandlerJson(HandleStatsJson, true)))
 	web.ServerPublicMux.Handle(pat.Get("/stats/charts"), web.APIHandler(publicHandlerJson(HandleStatsCharts, true)))
+	web.ServerPublicMux.Handle(pat.Get("/stats/activity"), web.APIHandler(publicHandlerJson(HandleActivityStats, true)))
+
+	// Exports and the read only api
+	for _, kind := range ExportKinds {
+		statsCPMux.Handle(pat.Get("/export/"+kind.Name), exportHandler(kind, exportAccessCP))
+		web.ServerPublicMux.Handle(pat.Get("/stats/export/"+kind.Name), exportHandler(kind, exportAccessPublic))
+		web.ServerPubliAPIMux.Handle(pat.Get("/stats/"+kind.Name), exportHandler(kind, exportAccessAPI))
+	}
 }
 
 type publicHandlerFunc func(w http.ResponseWriter, r *http.Request, publicAccess bool) (web.TemplateData, error)
@@ -80,6 +98,13 @@ func HandleStatsHtml(w http.ResponseWriter, r *http.Request, isPublicAccess bool
 		return templateData, common.ErrWithCaller(err)
 	}
 
+	if isPublicAccess {
+		// never show the api token on the public page
+		cop := *config
+		cop.APIToken = ""
+		config = &cop
+	}
+
 	templateData["Config"] = config
 
 	if confDeprecated.GetBool() {
@@ -130,10 +155,11 @@ OUTER:
 		GuildID:        ag.ID,
 		Public:         null.BoolFrom(formData.Public),
 		IgnoreChannels: null.StringFrom(stringedChannels),
+		ActivityStats:  formData.ActivityStats,
 		CreatedAt:      null.TimeFrom(time.Now()),
 	}
 
-	err := model.UpsertG(r.Context(), true, []string{"guild_id"}, boil.Whitelist("public", "ignore_channels"), boil.Infer())
+	err := model.UpsertG(r.Context(), true, []string{"guild_id"}, boil.Whitelist("public", "ignore_channels", "activity_stats"), boil.Infer())
 	if err == nil {
 		pubsub.EvictCacheSet(cachedConfig, ag.ID)
 		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKey))
@@ -144,6 +170,120 @@ OUTER:
 	return templateData, err
 }
 
+func HandleResetAPIToken(w http.ResponseWriter, r *http.Request) (web.TemplateData, error) {
+	ag, templateData := web.GetBaseCPContextData(r.Context())
+
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return templateData, err
+	}
+
+	model := &models.ServerStatsConfig{
+		GuildID:   ag.ID,
+		CreatedAt: null.TimeFrom(time.Now()),
+		APIToken:  hex.EncodeToString(b),
+	}
+
+	err = model.UpsertG(r.Context(), true, []string{"guild_id"}, boil.Whitelist("api_token"), boil.Infer())
+	if err == nil {
+		pubsub.EvictCacheSet(cachedConfig, ag.ID)
+		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyAPIToken))
+	}
+
+	WebConfigCache.Delete(int(ag.ID))
+
+	return templateData, err
+}
+
+type exportAccess int
+
+const (
+	exportAccessCP exportAccess = iota
+	exportAccessPublic
+	exportAccessAPI
+)
+
+// exportHandler serves the stats of kind as json or csv (?format=csv), in the range set by the from and to query params
+func exportHandler(kind *ExportKind, access exportAccess) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		activeGuild, _ := web.GetBaseCPContextData(r.Context())
+
+		conf := GetConfigWeb(activeGuild.ID)
+		if conf == nil {
+			http.Error(w, "failed retrieving config", http.StatusInternalServerError)
+			return
+		}
+
+		switch access {
+		case exportAccessPublic:
+			if !conf.Public {
+				http.Error(w, "public access has been disabled by the server admins", http.StatusForbidden)
+				return
+			}
+		case exportAccessAPI:
+			if !conf.Public && !checkAPIToken(r, conf) {
+				http.Error(w, "stats are private, a valid api token is required", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		// the api mux doesn't have the premium middleware, so check it directly for all of them
+		isPremium, err := premium.IsGuildPremium(activeGuild.ID)
+		if err != nil {
+			web.CtxLogger(r.Context()).WithError(err).Error("failed checking if guild is premium")
+			http.Error(w, "failed checking premium status", http.StatusInternalServerError)
+			return
+		}
+
+		query := r.URL.Query()
+		from, to, err := ParseExportRange(kind, query.Get("from"), query.Get("to"), time.Now(), isPremium)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rows, err := kind.Fetch(r.Context(), activeGuild.ID, from, to)
+		if err != nil {
+			web.CtxLogger(r.Context()).WithError(err).Error("failed retrieving stats export")
+			http.Error(w, "failed retrieving stats", http.StatusInternalServerError)
+			return
+		}
+
+		if query.Get("format") != "csv" {
+			w.Header().Set("content-type", "application/json")
+			web.LogIgnoreErr(json.NewEncoder(w).Encode(rows))
+			return
+		}
+
+		fileName := fmt.Sprintf("serverstats-%d-%s-%s-%s.csv", activeGuild.ID, kind.Name, from.Format("2006-01-02"), to.AddDate(0, 0, -1).Format("2006-01-02"))
+		w.Header().Set("content-type", "text/csv")
+		w.Header().Set("content-disposition", "attachment; filename=\""+fileName+"\"")
+
+		cw := csv.NewWriter(w)
+		cw.Write(kind.CSVHeader)
+		for _, v := range rows {
+			cw.Write(v.CSVRecord())
+		}
+		cw.Flush()
+		web.LogIgnoreErr(cw.Error())
+	})
+}
+
+// checkAPIToken checks the token in either the authorization header or the token query param
+func checkAPIToken(r *http.Request, conf *ServerStatsConfig) bool {
+	if conf.APIToken == "" {
+		return false
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(conf.APIToken)) == 1
+}
+
 type publicHandlerFuncJson func(w http.ResponseWriter, r *http.Request, publicAccess bool) interface{}
 
 func publicHandlerJson(inner publicHandlerFuncJson, public bool) web.CustomHandlerFunc {
@@ -221,6 +361,62 @@ func HandleStatsCharts(w http.ResponseWriter, r *http.Request, isPublicAccess bo
 	return stats
 }
 
+func HandleActivityStats(w http.ResponseWriter, r *http.Request, isPublicAccess bool) interface{} {
+	activeGuild, _ := web.GetBaseCPContextData(r.Context())
+
+	conf := GetConfigWeb(activeGuild.ID)
+	if conf == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil
+	}
+
+	if (!conf.Public && isPublicAccess) || !conf.ActivityStats {
+		return nil
+	}
+
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+	days = ClampActivityDays(days, premium.ContextPremium(r.Context()))
+
+	stats, err := RetrieveActivityStats(r.Context(), activeGuild.ID, time.Now(), days, 10)
+	if err != nil {
+		web.CtxLogger(r.Context()).WithError(err).Error("Failed retrieving activity stats")
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil
+	}
+
+	for _, cs := range stats.TopChannels {
+		cs.Name = discordgo.StrID(cs.ChannelID)
+		for _, channel := range activeGuild.Channels {
+			if channel.ID == cs.ChannelID {
+				cs.Name = channel.Name
+				break
+			}
+		}
+	}
+
+	userIDs := make([]int64, 0, len(stats.TopUsers))
+	for _, v := range stats.TopUsers {
+		userIDs = append(userIDs, v.UserID)
+	}
+
+	members, err := botrest.GetMembers(activeGuild.ID, userIDs...)
+	if err != nil {
+		web.CtxLogger(r.Context()).WithError(err).Error("Failed retrieving top chatters")
+	}
+
+	for _, u := range stats.TopUsers {
+		u.Name = discordgo.StrID(u.UserID)
+		for _, m := range members {
+			if m.User.ID == u.UserID {
+				u.Name = m.User.Username + "#" + m.User.Discriminator
+				break
+			}
+		}
+	}
+
+	return stats
+}
+
 func emptyChartData() *ChartResponse {
 	return &ChartResponse{
 		Days: 0,
@@ -90,4 +90,30 @@ var dbSchemas = []string{
 	// we don't care about indexing t of non-premium rows, this means we can also use it in the cleanup
 	// without needing to filter out premium rows, since they're not included in the index at all
 	`CREATE INDEX IF NOT EXISTS server_stats_periods_compressed_t_nonpremium_idx ON server_stats_periods_compressed(t) WHERE premium=false;`,
+	`ALTER TABLE server_stats_configs ADD COLUMN IF NOT EXISTS api_token TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE server_stats_configs ADD COLUMN IF NOT EXISTS activity_stats BOOLEAN NOT NULL DEFAULT FALSE;`,
+	`
+	CREATE TABLE IF NOT EXISTS server_stats_daily_user_activity (
+		guild_id BIGINT NOT NULL,
+		t DATE NOT NULL,
+		channel_id BIGINT NOT NULL,
+		user_id BIGINT NOT NULL,
+
+		count INT NOT NULL,
+
+		PRIMARY KEY(guild_id, t, channel_id, user_id)
+	);
+	`,
+	`CREATE INDEX IF NOT EXISTS server_stats_daily_user_activity_t_idx ON server_stats_daily_user_activity(t);`,
+	`
+	CREATE TABLE IF NOT EXISTS server_stats_hourly_activity (
+		guild_id BIGINT NOT NULL,
+		t TIMESTAMP WITH TIME ZONE NOT NULL,
+
+		count INT NOT NULL,
+
+		PRIMARY KEY(guild_id, t)
+	);
+	`,
+	`CREATE INDEX IF NOT EXISTS server_stats_hourly_activity_t_idx ON server_stats_hourly_activity(t);`,
 }
@@ -47,6 +47,10 @@ func RegisterPlugin() {
 type ServerStatsConfig struct {
 	Public         bool
 	IgnoreChannels string
+	APIToken       string
+
+	// ActivityStats enables recording of per user and per channel activity
+	ActivityStats bool
 
 	ParsedChannels []int64
 }
@@ -65,6 +69,8 @@ func configFromModel(model *models.ServerStatsConfig) *ServerStatsConfig {
 	conf := &ServerStatsConfig{
 		Public:         model.Public.Bool,
 		IgnoreChannels: model.IgnoreChannels.String,
+		APIToken:       model.APIToken,
+		ActivityStats:  model.ActivityStats,
 	}
 	conf.ParseChannels()
 
@@ -0,0 +1,100 @@
+package scheduledevents
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jonas747/dcmd/v3"
+	"github.com/jonas747/yagpdb/commands"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/scheduledevents2"
+	"github.com/jonas747/yagpdb/stdcommands/util"
+)
+
+// max events listed in the response, the control panel shows more
+const maxListed = 15
+
+var Command = &commands.YAGCommand{
+	Cooldown:             2,
+	CmdCategory:          commands.CategoryDebug,
+	HideFromCommandsPage: true,
+	Name:                 "scheduledevents",
+	Aliases:              []string{"sevents"},
+	Description:          "Lists the pending scheduled events of the current or specified server, -cancel or -run an event by id",
+	HideFromHelp:         true,
+	ArgSwitches: []*dcmd.ArgDef{
+		{Name: "server", Type: dcmd.BigInt, Default: int64(0)},
+		{Name: "cancel", Type: dcmd.BigInt, Default: int64(0)},
+		{Name: "run", Type: dcmd.BigInt, Default: int64(0)},
+	},
+	RunFunc: util.RequireBotAdmin(func(data *dcmd.Data) (interface{}, error) {
+		guildID := data.Switch("server").Int64()
+		if guildID == 0 && data.GuildData != nil {
+			guildID = data.GuildData.GS.ID
+		}
+
+		if guildID == 0 {
+			return "No server specified", nil
+		}
+
+		if id := data.Switch("cancel").Int64(); id != 0 {
+			found, err := scheduledevents2.CancelEvent(data.Context(), guildID, id)
+			if err != nil {
+				return nil, err
+			}
+
+			if !found {
+				return "No pending event with that id on the server", nil
+			}
+
+			return fmt.Sprintf("Cancelled event `#%d`", id), nil
+		}
+
+		if id := data.Switch("run").Int64(); id != 0 {
+			found, err := scheduledevents2.RunEventNow(data.Context(), guildID, id)
+			if err != nil {
+				return nil, err
+			}
+
+			if !found {
+				return "No pending event with that id on the server", nil
+			}
+
+			return fmt.Sprintf("Event `#%d` will run within a couple of seconds", id), nil
+		}
+
+		events, err := scheduledevents2.GetPendingEvents(data.Context(), guildID, maxListed+1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(events) < 1 {
+			return fmt.Sprintf("No pending scheduled events on `%d`", guildID), nil
+		}
+
+		var out strings.Builder
+		fmt.Fprintf(&out, "Pending scheduled events on `%d`:\n", guildID)
+		for i, v := range events {
+			if i >= maxListed {
+				out.WriteString("...and more, see the control panel for the full list")
+				break
+			}
+
+			fmt.Fprintf(&out, "`#%d` **%s** %s", v.ID, v.EventName, common.HumanizeTime(common.DurationPrecisionSeconds, v.TriggersAt))
+			if !scheduledevents2.IsRegisteredHandler(v.EventName) {
+				out.WriteString(" (no handler)")
+			}
+
+			if v.Retries > 0 {
+				fmt.Fprintf(&out, ", retried %d times", v.Retries)
+			}
+
+			fmt.Fprintf(&out, "\n`%s`\n", common.CutStringShort(string(v.Data), 100))
+			if v.Error.Valid {
+				fmt.Fprintf(&out, "Last error: `%s`\n", common.CutStringShort(v.Error.String, 100))
+			}
+		}
+
+		return out.String(), nil
+	}),
+}
@@ -26,7 +26,7 @@ func cmdFuncTopCommands(data *dcmd.Data) (interface{}, error) {
 	within := time.Now().Add(time.Duration(-hours) * time.Hour)
 
 	var results []*TopCommandsResult
-	err := common.GORM.Table(common.LoggedExecutedCommand{}.TableName()).Select("command, COUNT(id)").Where("created_at > ?", within).Group("command").Order("count(id) desc").Scan(&results).Error
+	err := common.GORM.Table(common.LoggedExecutedCommand{}.TableName()).Select("command, COUNT(id)").Where("created_at > ? AND status != ?", within, common.ExecutedCommandStatusDenied).Group("command").Order("count(id) desc").Scan(&results).Error
 	if err != nil {
 		return nil, err
 	}
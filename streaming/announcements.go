@@ -0,0 +1,170 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/jonas747/yagpdb/common"
+	"github.com/mediocregopher/radix/v3"
+)
+
+const (
+	DefaultAnnounceCooldown = 60 // minutes
+
+	// how long we keep track of announcements for editing them when the stream ends
+	announcementExpiry = 60 * 60 * 24 * 7
+)
+
+// Cooldown returns how long to wait before announcing the same member again, counted from when the stream ended
+func (c *Config) Cooldown() time.Duration {
+	if c.AnnounceCooldown < 1 {
+		return time.Minute * DefaultAnnounceCooldown
+	}
+
+	return time.Minute * time.Duration(c.AnnounceCooldown)
+}
+
+func KeyAnnouncementCooldown(guildID, memberID int64) string {
+	return fmt.Sprintf("streaming_announcement_sent:%d:%d", guildID, memberID)
+}
+
+// cooldown of streams found by polling, they're not tied to a member
+func keyPolledAnnouncementCooldown(guildID int64, platform, channel string) string {
+	return fmt.Sprintf("streaming_announcement_sent:%d:%s:%s", guildID, platform, channel)
+}
+
+func KeyAnnouncement(guildID, memberID int64) string {
+	return fmt.Sprintf("streaming_announcement:%d:%d", guildID, memberID)
+}
+
+// announcementState is the announcement of a members stream, kept so it can be edited when the stream ends
+type announcementState struct {
+	ChannelID int64
+	MessageID int64
+
+	// The original content of the announcement, restored if the stream comes back within the cooldown
+	Content string
+
+	DisplayName string
+	Platform    string
+	Title       string
+	URL         string
+	StartedAt   time.Time
+
+	Ended bool
+}
+
+func getAnnouncement(guildID, memberID int64) (*announcementState, error) {
+	var serialized []byte
+	err := common.RedisPool.Do(radix.Cmd(&serialized, "GET", KeyAnnouncement(guildID, memberID)))
+	if err != nil || len(serialized) < 1 {
+		return nil, err
+	}
+
+	var state *announcementState
+	err = json.Unmarshal(serialized, &state)
+	return state, err
+}
+
+func storeAnnouncement(guildID, memberID int64, state *announcementState) error {
+	serialized, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return common.RedisPool.Do(radix.FlatCmd(nil, "SET", KeyAnnouncement(guildID, memberID), serialized, "EX", announcementExpiry))
+}
+
+// updateAnnouncementTitle keeps track of the last known title of the stream
+func updateAnnouncementTitle(guildID, memberID int64, title string) {
+	state, err := getAnnouncement(guildID, memberID)
+	if err != nil || state == nil || state.Ended || state.Title == title {
+		return
+	}
+
+	state.Title = title
+	err = storeAnnouncement(guildID, memberID, state)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed updating streaming announcement")
+	}
+}
+
+// endAnnouncement is called when the stream of a member ends, the cooldown restarts from now
+// so that streams that briefly drop are not announced again
+func endAnnouncement(config *Config, guildID, memberID int64) {
+	err := common.RedisPool.Do(radix.FlatCmd(nil, "SET", KeyAnnouncementCooldown(guildID, memberID), "1", "EX", int(config.Cooldown().Seconds())))
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed refreshing streaming announcement cooldown")
+	}
+
+	if !config.EditEndedAnnouncements {
+		return
+	}
+
+	state, err := getAnnouncement(guildID, memberID)
+	if err != nil || state == nil || state.Ended {
+		return
+	}
+
+	content := endedAnnouncementContent(state.DisplayName, state.Platform, state.Title, state.URL, state.StartedAt)
+	_, err = common.BotSession.ChannelMessageEdit(state.ChannelID, state.MessageID, content)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Debug("failed editing ended streaming announcement")
+		common.RedisPool.Do(radix.Cmd(nil, "DEL", KeyAnnouncement(guildID, memberID)))
+		return
+	}
+
+	state.Ended = true
+	storeAnnouncement(guildID, memberID, state)
+}
+
+// resumeAnnouncement restores the announcement of a stream that came back within the cooldown
+func resumeAnnouncement(guildID, memberID int64) {
+	state, err := getAnnouncement(guildID, memberID)
+	if err != nil || state == nil || !state.Ended {
+		return
+	}
+
+	_, err = common.BotSession.ChannelMessageEdit(state.ChannelID, state.MessageID, state.Content)
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Debug("failed restoring streaming announcement")
+		return
+	}
+
+	state.Ended = false
+	storeAnnouncement(guildID, memberID, state)
+}
+
+func endedAnnouncementContent(name, platform, title, url string, startedAt time.Time) string {
+	if platform == "" {
+		platform = "stream"
+	}
+
+	content := fmt.Sprintf("**%s** was live on %s", name, platform)
+	if title != "" {
+		content += ": **" + title + "**"
+	}
+
+	if !startedAt.IsZero() {
+		content += "\nStreamed for " + common.HumanizeDuration(common.DurationPrecisionMinutes, time.Since(startedAt))
+	}
+
+	if url != "" {
+		content += "\nVOD: <" + vodURL(url) + ">"
+	}
+
+	return content
+}
+
+var twitchChannelURLRegex = regexp.MustCompile(`(?i)^https?://(?:www\.)?twitch\.tv/([a-z0-9_]+)/?$`)
+
+// vodURL returns where the recording of the stream can be found, youtube streams keep the same url
+func vodURL(url string) string {
+	if m := twitchChannelURLRegex.FindStringSubmatch(url); m != nil {
+		return "https://www.twitch.tv/" + m[1] + "/videos"
+	}
+
+	return url
+}
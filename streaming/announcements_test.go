@@ -0,0 +1,32 @@
+package streaming
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVodURL(t *testing.T) {
+	cases := map[string]string{
+		"https://www.twitch.tv/jonas747":              "https://www.twitch.tv/jonas747/videos",
+		"https://twitch.tv/Jonas747/":                 "https://www.twitch.tv/Jonas747/videos",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ": "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+	}
+
+	for in, expected := range cases {
+		if got := vodURL(in); got != expected {
+			t.Errorf("vodURL(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestCooldown(t *testing.T) {
+	c := &Config{}
+	if c.Cooldown() != time.Hour {
+		t.Errorf("unset cooldown should use the default, got %s", c.Cooldown())
+	}
+
+	c.AnnounceCooldown = 15
+	if c.Cooldown() != time.Minute*15 {
+		t.Errorf("expected 15m, got %s", c.Cooldown())
+	}
+}
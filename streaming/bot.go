@@ -1,10 +1,10 @@
 package streaming
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"emperror.dev/errors"
 
@@ -258,6 +258,9 @@ func CheckPresenceSparse(client radix.Client, config *Config, p *discordgo.Prese
 		client.Do(radix.FlatCmd(&markedNow, "SADD", KeyCurrentlyStreaming(gs.ID), p.User.ID))
 		if !markedNow {
 			// Already marked
+			if config.EditEndedAnnouncements {
+				updateAnnouncementTitle(gs.ID, p.User.ID, mainActivity.Details)
+			}
 			return nil
 		}
 
@@ -268,7 +271,7 @@ func CheckPresenceSparse(client radix.Client, config *Config, p *discordgo.Prese
 				return errors.WithStackIf(err)
 			}
 
-			SendStreamingAnnouncement(config, gs, ms, mainActivity.URL, mainActivity.State, mainActivity.Details, mainActivity.Name)
+			SendStreamingAnnouncement(config, gs, ms, activityStream(mainActivity))
 		}
 	} else {
 		// Not streaming
@@ -278,6 +281,15 @@ func CheckPresenceSparse(client radix.Client, config *Config, p *discordgo.Prese
 	return nil
 }
 
+func activityStream(activity *discordgo.Game) *Stream {
+	return &Stream{
+		URL:      activity.URL,
+		Game:     activity.State,
+		Title:    activity.Details,
+		Platform: activity.Name,
+	}
+}
+
 func retrieveMainActivity(p *discordgo.Presence) *discordgo.Game {
 	for _, v := range p.Activities {
 		if v.Type == discordgo.GameTypeStreaming {
@@ -317,12 +329,20 @@ func CheckPresence(client radix.Client, config *Config, ms *dstate.MemberState,
 		client.Do(radix.FlatCmd(&markedNow, "SADD", KeyCurrentlyStreaming(gs.ID), ms.User.ID))
 		if !markedNow {
 			// Already marked
+			if config.EditEndedAnnouncements {
+				updateAnnouncementTitle(gs.ID, ms.User.ID, ms.Presence.Game.Details)
+			}
 			return nil
 		}
 
 		// Send the streaming announcement if enabled
 		if config.AnnounceChannel != 0 && config.AnnounceMessage != "" {
-			SendStreamingAnnouncement(config, gs, ms, ms.Presence.Game.URL, ms.Presence.Game.State, ms.Presence.Game.Details, ms.Presence.Game.Name)
+			SendStreamingAnnouncement(config, gs, ms, &Stream{
+				URL:      ms.Presence.Game.URL,
+				Game:     ms.Presence.Game.State,
+				Title:    ms.Presence.Game.Details,
+				Platform: ms.Presence.Game.Name,
+			})
 		}
 
 	} else {
@@ -350,6 +370,11 @@ func (config *Config) MeetsRequirements(roles []int64, activityState, activityDe
 		}
 	}
 
+	return config.MeetsStreamFilters(activityState, activityDetails)
+}
+
+// MeetsStreamFilters returns true if the game and title of the stream matches the game and title regexes
+func (config *Config) MeetsStreamFilters(activityState, activityDetails string) bool {
 	if strings.TrimSpace(config.GameRegex) != "" {
 		gameName := activityState
 		compiledRegex, err := regexp.Compile(strings.TrimSpace(config.GameRegex))
@@ -376,9 +401,15 @@ func (config *Config) MeetsRequirements(roles []int64, activityState, activityDe
 }
 
 func RemoveStreaming(client radix.Client, config *Config, guildID int64, memberID int64, currentRoles []int64) {
-	client.Do(radix.FlatCmd(nil, "SREM", KeyCurrentlyStreaming(guildID), memberID))
+	var removed int
+	client.Do(radix.FlatCmd(&removed, "SREM", KeyCurrentlyStreaming(guildID), memberID))
 	go RemoveStreamingRole(guildID, memberID, config.GiveRole, currentRoles)
 
+	if removed > 0 {
+		// the stream ended
+		go endAnnouncement(config, guildID, memberID)
+	}
+
 	// Was not streaming before if we removed 0 elements
 	// var removed bool
 	// client.Do(radix.FlatCmd(&removed, "SREM", KeyCurrentlyStreaming(guildID), memberID))
@@ -387,19 +418,31 @@ func RemoveStreaming(client radix.Client, config *Config, guildID int64, memberI
 	// }
 }
 
-func SendStreamingAnnouncement(config *Config, guild *dstate.GuildSet, ms *dstate.MemberState, url string, gameName string, streamTitle string, streamPlatform string) {
-	// Only send one announcment every 1 hour
+// SendStreamingAnnouncement sends the announcement message for the stream, ms is nil for polled streams
+func SendStreamingAnnouncement(config *Config, guild *dstate.GuildSet, ms *dstate.MemberState, stream *Stream) *discordgo.Message {
+	// Only send one announcment per cooldown, the cooldown is refreshed when the stream ends
+	// so that streams that briefly drop are not announced again
 	var resp string
-	key := fmt.Sprintf("streaming_announcement_sent:%d:%d", guild.ID, ms.User.ID)
-	err := common.RedisPool.Do(radix.Cmd(&resp, "SET", key, "1", "EX", "3600", "NX"))
+	var key string
+	if ms != nil {
+		key = KeyAnnouncementCooldown(guild.ID, ms.User.ID)
+	} else {
+		key = keyPolledAnnouncementCooldown(guild.ID, stream.Platform, stream.Channel)
+	}
+
+	err := common.RedisPool.Do(radix.FlatCmd(&resp, "SET", key, "1", "EX", int(config.Cooldown().Seconds()), "NX"))
 	if err != nil {
 		logger.WithError(err).Error("failed setting streaming announcment cooldown")
-		return
+		return nil
 	}
 
 	if resp != "OK" {
-		logger.Info("streaming announcment cooldown: ", ms.User.ID)
-		return
+		logger.Info("streaming announcment cooldown: ", key)
+		if ms != nil && config.EditEndedAnnouncements {
+			// the stream came back, undo the "ended" edit
+			resumeAnnouncement(guild.ID, ms.User.ID)
+		}
+		return nil
 	}
 
 	// make sure the channel exists
@@ -416,34 +459,54 @@ func SendStreamingAnnouncement(config *Config, guild *dstate.GuildSet, ms *dstat
 		config.Save(guild.ID)
 
 		logger.WithField("guild", guild.ID).WithField("channel", config.AnnounceChannel).Warn("Channel not found in state, not sending streaming announcement")
-		return
+		return nil
 	}
 
 	go analytics.RecordActiveUnit(guild.ID, &Plugin{}, "sent_streaming_announcement")
 
 	ctx := templates.NewContext(guild, nil, ms)
-	// ctx.Data["URL"] = ms.PresenceGame.URL
-	// ctx.Data["url"] = ms.PresenceGame.URL
-	// ctx.Data["Game"] = ms.PresenceGame.State
-	// ctx.Data["StreamTitle"] = ms.PresenceGame.Details
-	// ctx.Data["StreamPlatform"] = ms.PresenceGame.Name
-
-	ctx.Data["URL"] = url
-	ctx.Data["url"] = url
-	ctx.Data["Game"] = gameName
-	ctx.Data["StreamTitle"] = streamTitle
-	ctx.Data["StreamPlatform"] = streamPlatform
+	if ms == nil {
+		// not a member, but this keeps the messages using {{.User.Username}} working
+		ctx.Data["User"] = &discordgo.User{Username: stream.DisplayName}
+		ctx.Data["user"] = ctx.Data["User"]
+	}
+
+	ctx.Data["URL"] = stream.URL
+	ctx.Data["url"] = stream.URL
+	ctx.Data["Game"] = stream.Game
+	ctx.Data["StreamTitle"] = stream.Title
+	ctx.Data["StreamPlatform"] = stream.Platform
 
 	out, err := ctx.Execute(config.AnnounceMessage)
 	if err != nil {
 		logger.WithError(err).WithField("guild", guild.ID).Warn("Failed executing template")
-		return
+		return nil
 	}
 
 	m, err := common.BotSession.ChannelMessageSendComplex(config.AnnounceChannel, ctx.MessageSend(out))
-	if err == nil && ctx.CurrentFrame.DelResponse {
+	if err != nil {
+		return nil
+	}
+
+	if ctx.CurrentFrame.DelResponse {
 		templates.MaybeScheduledDeleteMessage(guild.ID, config.AnnounceChannel, m.ID, ctx.CurrentFrame.DelResponseDelay)
+	} else if ms != nil && config.EditEndedAnnouncements {
+		err = storeAnnouncement(guild.ID, ms.User.ID, &announcementState{
+			ChannelID:   config.AnnounceChannel,
+			MessageID:   m.ID,
+			Content:     out,
+			DisplayName: ms.User.Username,
+			Platform:    stream.Platform,
+			Title:       stream.Title,
+			URL:         stream.URL,
+			StartedAt:   time.Now(),
+		})
+		if err != nil {
+			logger.WithError(err).WithField("guild", guild.ID).Error("failed storing streaming announcement")
+		}
 	}
+
+	return m
 }
 
 func GiveStreamingRole(guildID, memberID, streamingRole int64, currentUserRoles []int64) {
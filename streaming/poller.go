@@ -0,0 +1,264 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonas747/discordgo"
+	"github.com/jonas747/yagpdb/bot/botrest"
+	"github.com/jonas747/yagpdb/common"
+	"github.com/jonas747/yagpdb/common/backgroundworkers"
+	"github.com/jonas747/yagpdb/common/config"
+	"github.com/mediocregopher/radix/v3"
+)
+
+var (
+	confTwitchClientID     = config.RegisterOption("yagpdb.twitch.clientid", "Twitch client id, used to poll twitch streams", "")
+	confTwitchClientSecret = config.RegisterOption("yagpdb.twitch.clientsecret", "Twitch client secret", "")
+	confYoutubeAPIKey      = config.RegisterOption("yagpdb.streaming.youtube_api_key", "Youtube data api key, used to poll youtube livestreams", "")
+)
+
+const (
+	// Set of guilds that has polled channels
+	KeyPolledGuilds = "streaming_polled_guilds"
+
+	MaxPolledChannels = 5
+
+	PollInterval = time.Minute * 2
+	// youtube requests count towards a daily quota, so they're only done every couple of polls
+	youtubePollEvery = 5
+)
+
+// Hash of the polled streams currently live in the guild, keyed by platform:channel
+func KeyPolledLive(guildID int64) string { return "streaming_polled_live:" + discordgo.StrID(guildID) }
+
+// polledLiveStream is stored in KeyPolledLive while the stream is live
+type polledLiveStream struct {
+	Stream    *Stream
+	ChannelID int64
+	MessageID int64
+}
+
+var _ backgroundworkers.BackgroundWorkerPlugin = (*Plugin)(nil)
+
+// RunBackgroundWorker implements backgroundworkers.BackgroundWorkerPlugin
+func (p *Plugin) RunBackgroundWorker() {
+	var sources []StreamSource
+	if confTwitchClientID.GetString() != "" && confTwitchClientSecret.GetString() != "" {
+		sources = append(sources, NewTwitchSource(confTwitchClientID.GetString(), confTwitchClientSecret.GetString()))
+	}
+
+	if confYoutubeAPIKey.GetString() != "" {
+		sources = append(sources, NewYoutubeSource(confYoutubeAPIKey.GetString()))
+	}
+
+	if len(sources) < 1 {
+		logger.Info("No twitch or youtube credentials set, not polling streams")
+	}
+
+	ticker := time.NewTicker(PollInterval)
+	tick := 0
+	for {
+		select {
+		case wg := <-p.stopBGWorker:
+			wg.Done()
+			return
+		case <-ticker.C:
+		}
+
+		for _, source := range sources {
+			if source.Platform() == youtubePlatform && tick%youtubePollEvery != 0 {
+				continue
+			}
+
+			pollSource(source)
+		}
+
+		tick++
+	}
+}
+
+// StopBackgroundWorker implements backgroundworkers.BackgroundWorkerPlugin
+func (p *Plugin) StopBackgroundWorker(wg *sync.WaitGroup) {
+	p.stopBGWorker <- wg
+}
+
+func pollSource(source StreamSource) {
+	var guilds []int64
+	err := common.RedisPool.Do(radix.Cmd(&guilds, "SMEMBERS", KeyPolledGuilds))
+	if err != nil {
+		logger.WithError(err).Error("failed retrieving polled guilds")
+		return
+	}
+
+	configs := make(map[int64]*Config)
+	var channels []string
+	for _, g := range guilds {
+		config, err := GetConfig(g)
+		if err != nil {
+			logger.WithError(err).WithField("guild", g).Error("failed retrieving streaming config")
+			continue
+		}
+
+		configs[g] = config
+		for _, c := range config.PolledChannels(source.Platform()) {
+			if !common.ContainsStringSlice(channels, c) {
+				channels = append(channels, c)
+			}
+		}
+	}
+
+	if len(channels) < 1 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), PollInterval/2)
+	defer cancel()
+
+	live, err := source.LiveStreams(ctx, channels)
+	if err != nil {
+		// don't touch the current streams, we don't know if they ended
+		logger.WithError(err).WithField("platform", source.Platform()).Error("failed polling streams")
+		return
+	}
+
+	for guildID, config := range configs {
+		polled := config.PolledChannels(source.Platform())
+		for _, c := range polled {
+			stream, ok := live[strings.ToLower(c)]
+			if ok && stream == nil {
+				// failed checking this channel, we don't know if it ended
+				continue
+			}
+
+			err := updatePolledStream(guildID, config, source.Platform(), c, stream)
+			if err != nil {
+				logger.WithError(err).WithField("guild", guildID).WithField("channel", c).Error("failed updating polled stream")
+			}
+		}
+
+		prunePolledStreams(guildID, source.Platform(), polled)
+	}
+}
+
+// prunePolledStreams removes the streams of channels that are no longer polled
+func prunePolledStreams(guildID int64, platform string, polled []string) {
+	var fields []string
+	err := common.RedisPool.Do(radix.Cmd(&fields, "HKEYS", KeyPolledLive(guildID)))
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed retrieving polled streams")
+		return
+	}
+
+OUTER:
+	for _, field := range fields {
+		if !strings.HasPrefix(field, platform+":") {
+			continue
+		}
+
+		for _, c := range polled {
+			if field == platform+":"+strings.ToLower(c) {
+				continue OUTER
+			}
+		}
+
+		common.RedisPool.Do(radix.Cmd(nil, "HDEL", KeyPolledLive(guildID), field))
+	}
+}
+
+// updatePolledStream announces the stream if it's new, or edits the announcement if it ended (stream is nil)
+func updatePolledStream(guildID int64, config *Config, platform, channel string, stream *Stream) error {
+	field := platform + ":" + strings.ToLower(channel)
+
+	var serialized []byte
+	err := common.RedisPool.Do(radix.Cmd(&serialized, "HGET", KeyPolledLive(guildID), field))
+	if err != nil {
+		return err
+	}
+
+	var current *polledLiveStream
+	if len(serialized) > 0 {
+		err = json.Unmarshal(serialized, &current)
+		if err != nil {
+			return err
+		}
+	}
+
+	if current != nil && (stream == nil || stream.ID != current.Stream.ID) {
+		// ended, or a new stream started in the meantime
+		endPolledStream(guildID, config, current)
+		err = common.RedisPool.Do(radix.Cmd(nil, "HDEL", KeyPolledLive(guildID), field))
+		if err != nil {
+			return err
+		}
+
+		current = nil
+	}
+
+	if stream == nil {
+		return nil
+	}
+
+	if current != nil {
+		if current.Stream.Title == stream.Title {
+			return nil
+		}
+
+		// keep track of the last known title for when the stream ends
+		current.Stream.Title = stream.Title
+		serialized, err = json.Marshal(current)
+		if err != nil {
+			return err
+		}
+
+		return common.RedisPool.Do(radix.Cmd(nil, "HSET", KeyPolledLive(guildID), field, string(serialized)))
+	}
+
+	if !config.Enabled || config.AnnounceChannel == 0 || config.AnnounceMessage == "" || !config.MeetsStreamFilters(stream.Game, stream.Title) {
+		return nil
+	}
+
+	gs, err := botrest.GetGuild(guildID)
+	if err != nil {
+		return err
+	}
+
+	state := &polledLiveStream{
+		Stream:    stream,
+		ChannelID: config.AnnounceChannel,
+	}
+
+	if m := SendStreamingAnnouncement(config, gs, nil, stream); m != nil {
+		state.MessageID = m.ID
+	}
+
+	serialized, err = json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return common.RedisPool.Do(radix.Cmd(nil, "HSET", KeyPolledLive(guildID), field, string(serialized)))
+}
+
+func endPolledStream(guildID int64, config *Config, current *polledLiveStream) {
+	s := current.Stream
+
+	// restart the cooldown from when the stream ended
+	err := common.RedisPool.Do(radix.FlatCmd(nil, "SET", keyPolledAnnouncementCooldown(guildID, s.Platform, s.Channel), "1", "EX", int(config.Cooldown().Seconds())))
+	if err != nil {
+		logger.WithError(err).WithField("guild", guildID).Error("failed refreshing streaming announcement cooldown")
+	}
+
+	if current.MessageID == 0 || !config.EditEndedAnnouncements {
+		return
+	}
+
+	content := endedAnnouncementContent(s.DisplayName, s.Platform, s.Title, s.URL, s.StartedAt)
+	_, err = common.BotSession.ChannelMessageEdit(current.ChannelID, current.MessageID, content)
+	if err != nil {
+		logger.WithError(err).WithField("channel", current.ChannelID).Debug("failed editing ended stream announcement")
+	}
+}
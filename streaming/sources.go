@@ -0,0 +1,403 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jonas747/yagpdb/common"
+)
+
+// Stream is a live stream, either found through the presence of a member or by polling a streaming platform directly
+type Stream struct {
+	Platform string
+
+	// The twitch login or youtube channel id of the streamer, only set for polled streams
+	Channel     string
+	DisplayName string
+
+	// Unique per stream, used to tell a new stream from a old one on the same channel
+	ID string
+
+	URL       string
+	Title     string
+	Game      string
+	StartedAt time.Time
+}
+
+// StreamSource is a streaming platform that can be polled for live streams
+type StreamSource interface {
+	// Platform returns the name of the platform, available as StreamPlatform in the announcement message
+	Platform() string
+
+	// LiveStreams returns the streams of the channels that are currently live, keyed by the lowercased channel.
+	// Channels that could not be checked can be included with a nil stream, their current state is then kept.
+	LiveStreams(ctx context.Context, channels []string) (map[string]*Stream, error)
+}
+
+const (
+	twitchPlatform  = "Twitch"
+	youtubePlatform = "YouTube"
+)
+
+var sourceHTTPClient = &http.Client{
+	Timeout: time.Second * 15,
+}
+
+func getJSON(ctx context.Context, client *http.Client, req *http.Request, dst interface{}) error {
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &statusError{StatusCode: resp.StatusCode}
+	}
+
+	return errors.WrapIf(json.NewDecoder(resp.Body).Decode(dst), "json.decode")
+}
+
+type statusError struct {
+	StatusCode int
+}
+
+func (s *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", s.StatusCode)
+}
+
+// TwitchSource polls the twitch helix api, it authenticates using the client credentials flow
+type TwitchSource struct {
+	ClientID     string
+	ClientSecret string
+
+	APIURL  string
+	AuthURL string
+	Client  *http.Client
+
+	tokenMu      sync.Mutex
+	token        string
+	tokenExpires time.Time
+}
+
+func NewTwitchSource(clientID, clientSecret string) *TwitchSource {
+	return &TwitchSource{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		APIURL:       "https://api.twitch.tv/helix",
+		AuthURL:      "https://id.twitch.tv/oauth2/token",
+		Client:       sourceHTTPClient,
+	}
+}
+
+func (t *TwitchSource) Platform() string {
+	return twitchPlatform
+}
+
+func (t *TwitchSource) getToken(ctx context.Context) (string, error) {
+	t.tokenMu.Lock()
+	defer t.tokenMu.Unlock()
+
+	if t.token != "" && time.Now().Before(t.tokenExpires) {
+		return t.token, nil
+	}
+
+	form := url.Values{
+		"client_id":     {t.ClientID},
+		"client_secret": {t.ClientSecret},
+		"grant_type":    {"client_credentials"},
+	}
+
+	req, err := http.NewRequest("POST", t.AuthURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	err = getJSON(ctx, t.Client, req, &resp)
+	if err != nil {
+		return "", errors.WrapIf(err, "twitch.auth")
+	}
+
+	t.token = resp.AccessToken
+	// refresh it a bit early
+	t.tokenExpires = time.Now().Add(time.Duration(resp.ExpiresIn)*time.Second - time.Minute)
+
+	return t.token, nil
+}
+
+func (t *TwitchSource) resetToken() {
+	t.tokenMu.Lock()
+	t.token = ""
+	t.tokenMu.Unlock()
+}
+
+type twitchStreamsResponse struct {
+	Data []struct {
+		ID        string    `json:"id"`
+		UserLogin string    `json:"user_login"`
+		UserName  string    `json:"user_name"`
+		GameName  string    `json:"game_name"`
+		Type      string    `json:"type"`
+		Title     string    `json:"title"`
+		StartedAt time.Time `json:"started_at"`
+	} `json:"data"`
+}
+
+// max user_login params per request
+const twitchMaxLogins = 100
+
+func (t *TwitchSource) LiveStreams(ctx context.Context, channels []string) (map[string]*Stream, error) {
+	token, err := t.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*Stream)
+	for i := 0; i < len(channels); i += twitchMaxLogins {
+		end := i + twitchMaxLogins
+		if end > len(channels) {
+			end = len(channels)
+		}
+
+		query := url.Values{}
+		for _, v := range channels[i:end] {
+			query.Add("user_login", strings.ToLower(v))
+		}
+
+		req, err := http.NewRequest("GET", t.APIURL+"/streams?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Client-Id", t.ClientID)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		var resp twitchStreamsResponse
+		err = getJSON(ctx, t.Client, req, &resp)
+		if err != nil {
+			if se, ok := err.(*statusError); ok && se.StatusCode == http.StatusUnauthorized {
+				// token expired or got revoked, get a new one next time
+				t.resetToken()
+			}
+
+			return nil, errors.WrapIf(err, "twitch.streams")
+		}
+
+		for _, v := range resp.Data {
+			if v.Type != "live" {
+				continue
+			}
+
+			login := strings.ToLower(v.UserLogin)
+			result[login] = &Stream{
+				Platform:    t.Platform(),
+				Channel:     login,
+				DisplayName: v.UserName,
+				ID:          v.ID,
+				URL:         "https://www.twitch.tv/" + login,
+				Title:       v.Title,
+				Game:        v.GameName,
+				StartedAt:   v.StartedAt,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// YoutubeSource polls the youtube data api, it looks at the latest uploads of each channel instead of using
+// search requests as those cost 100 quota units each, this way a poll costs about 1 unit per channel
+type YoutubeSource struct {
+	APIKey string
+
+	APIURL string
+	Client *http.Client
+}
+
+func NewYoutubeSource(apiKey string) *YoutubeSource {
+	return &YoutubeSource{
+		APIKey: apiKey,
+		APIURL: "https://www.googleapis.com/youtube/v3",
+		Client: sourceHTTPClient,
+	}
+}
+
+func (y *YoutubeSource) Platform() string {
+	return youtubePlatform
+}
+
+const (
+	// max ids per channels.list and videos.list request
+	youtubeMaxIDs = 50
+
+	// livestreams show up in the uploads playlist, so only the latest few uploads needs to be checked
+	youtubeRecentUploads = 5
+)
+
+type youtubeChannelsResponse struct {
+	Items []struct {
+		ID             string `json:"id"`
+		ContentDetails struct {
+			RelatedPlaylists struct {
+				Uploads string `json:"uploads"`
+			} `json:"relatedPlaylists"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+type youtubePlaylistItemsResponse struct {
+	Items []struct {
+		ContentDetails struct {
+			VideoID string `json:"videoId"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+type youtubeVideosResponse struct {
+	Items []struct {
+		ID      string `json:"id"`
+		Snippet struct {
+			ChannelID            string `json:"channelId"`
+			ChannelTitle         string `json:"channelTitle"`
+			Title                string `json:"title"`
+			LiveBroadcastContent string `json:"liveBroadcastContent"`
+		} `json:"snippet"`
+		LiveStreamingDetails struct {
+			ActualStartTime time.Time `json:"actualStartTime"`
+		} `json:"liveStreamingDetails"`
+	} `json:"items"`
+}
+
+func (y *YoutubeSource) get(ctx context.Context, endpoint string, query url.Values, dst interface{}) error {
+	query.Set("key", y.APIKey)
+
+	req, err := http.NewRequest("GET", y.APIURL+"/"+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	return errors.WrapIf(getJSON(ctx, y.Client, req, dst), "youtube."+endpoint)
+}
+
+// LiveStreams implements StreamSource, channels that could not be checked are logged and set to nil in the result
+// so that their current state is kept, an error is only returned if no channel could be checked
+func (y *YoutubeSource) LiveStreams(ctx context.Context, channels []string) (map[string]*Stream, error) {
+	result := make(map[string]*Stream)
+	failed := make(map[string]bool)
+	var lastErr error
+
+	skip := func(err error, channels ...string) {
+		lastErr = err
+		for _, c := range channels {
+			logger.WithError(err).WithField("channel", c).Error("failed checking youtube channel")
+			failed[strings.ToLower(c)] = true
+		}
+	}
+
+	// the ids of the latest uploads, and the channel they belong to
+	videoChannels := make(map[string]string)
+	var videoIDs []string
+
+	for i := 0; i < len(channels); i += youtubeMaxIDs {
+		end := i + youtubeMaxIDs
+		if end > len(channels) {
+			end = len(channels)
+		}
+
+		var channelsResp youtubeChannelsResponse
+		err := y.get(ctx, "channels", url.Values{
+			"part":       {"contentDetails"},
+			"id":         {strings.Join(channels[i:end], ",")},
+			"maxResults": {strconv.Itoa(youtubeMaxIDs)},
+		}, &channelsResp)
+		if err != nil {
+			skip(err, channels[i:end]...)
+			continue
+		}
+
+		// unknown channels are simply not live
+		for _, c := range channelsResp.Items {
+			var itemsResp youtubePlaylistItemsResponse
+			err := y.get(ctx, "playlistItems", url.Values{
+				"part":       {"contentDetails"},
+				"playlistId": {c.ContentDetails.RelatedPlaylists.Uploads},
+				"maxResults": {strconv.Itoa(youtubeRecentUploads)},
+			}, &itemsResp)
+			if err != nil {
+				skip(err, c.ID)
+				continue
+			}
+
+			for _, item := range itemsResp.Items {
+				videoChannels[item.ContentDetails.VideoID] = c.ID
+				videoIDs = append(videoIDs, item.ContentDetails.VideoID)
+			}
+		}
+	}
+
+	for i := 0; i < len(videoIDs); i += youtubeMaxIDs {
+		end := i + youtubeMaxIDs
+		if end > len(videoIDs) {
+			end = len(videoIDs)
+		}
+
+		var videosResp youtubeVideosResponse
+		err := y.get(ctx, "videos", url.Values{
+			"part": {"snippet,liveStreamingDetails"},
+			"id":   {strings.Join(videoIDs[i:end], ",")},
+		}, &videosResp)
+		if err != nil {
+			var failed []string
+			for _, v := range videoIDs[i:end] {
+				if !common.ContainsStringSlice(failed, videoChannels[v]) {
+					failed = append(failed, videoChannels[v])
+				}
+			}
+
+			skip(err, failed...)
+			continue
+		}
+
+		for _, video := range videosResp.Items {
+			if video.Snippet.LiveBroadcastContent != "live" {
+				continue
+			}
+
+			channel := videoChannels[video.ID]
+			result[strings.ToLower(channel)] = &Stream{
+				Platform:    y.Platform(),
+				Channel:     channel,
+				DisplayName: video.Snippet.ChannelTitle,
+				ID:          video.ID,
+				URL:         "https://www.youtube.com/watch?v=" + video.ID,
+				Title:       video.Snippet.Title,
+				StartedAt:   video.LiveStreamingDetails.ActualStartTime,
+			}
+		}
+	}
+
+	if len(failed) >= len(channels) {
+		// nothing could be checked, probably a bad api key or the quota ran out
+		return nil, lastErr
+	}
+
+	for c := range failed {
+		if _, ok := result[c]; !ok {
+			result[c] = nil
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package streaming
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTwitchSource(t *testing.T) {
+	tokenRequests := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if r.FormValue("client_id") != "id" || r.FormValue("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	})
+	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Client-Id") != "id" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		logins := r.URL.Query()["user_login"]
+		if len(logins) != 2 || logins[0] != "somestreamer" || logins[1] != "offline" {
+			t.Errorf("unexpected user_login params: %v", logins)
+		}
+
+		w.Write([]byte(`{"data":[{"id":"123","user_login":"SomeStreamer","user_name":"SomeStreamer","game_name":"Chess","type":"live","title":"chess stream","started_at":"2021-01-01T00:00:00Z"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	source := NewTwitchSource("id", "secret")
+	source.APIURL = server.URL
+	source.AuthURL = server.URL + "/token"
+
+	for i := 0; i < 2; i++ {
+		live, err := source.LiveStreams(context.Background(), []string{"SomeStreamer", "offline"})
+		if err != nil {
+			t.Fatal("failed retrieving streams: ", err)
+		}
+
+		if len(live) != 1 {
+			t.Fatalf("expected 1 live stream, got %d", len(live))
+		}
+
+		stream := live["somestreamer"]
+		if stream == nil || stream.ID != "123" || stream.Game != "Chess" || stream.URL != "https://www.twitch.tv/somestreamer" || stream.Platform != "Twitch" {
+			t.Errorf("unexpected stream: %#v", stream)
+		}
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("expected the token to be reused, got %d token requests", tokenRequests)
+	}
+}
+
+func TestYoutubeSource(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/channels", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "UClive,UCoffline,UCbroken" {
+			t.Errorf("unexpected channel ids: %s", r.URL.Query().Get("id"))
+		}
+
+		w.Write([]byte(`{"items":[{"id":"UClive","contentDetails":{"relatedPlaylists":{"uploads":"UUlive"}}},{"id":"UCoffline","contentDetails":{"relatedPlaylists":{"uploads":"UUoffline"}}},{"id":"UCbroken","contentDetails":{"relatedPlaylists":{"uploads":"UUbroken"}}}]}`))
+	})
+	mux.HandleFunc("/playlistItems", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("playlistId") {
+		case "UUlive":
+			w.Write([]byte(`{"items":[{"contentDetails":{"videoId":"abc"}},{"contentDetails":{"videoId":"old"}}]}`))
+		case "UUoffline":
+			w.Write([]byte(`{"items":[{"contentDetails":{"videoId":"vod"}}]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/videos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "abc,old,vod" {
+			t.Errorf("unexpected video ids: %s", r.URL.Query().Get("id"))
+		}
+
+		w.Write([]byte(`{"items":[{"id":"abc","snippet":{"channelId":"UClive","channelTitle":"Live channel","title":"live now","liveBroadcastContent":"live"},"liveStreamingDetails":{"actualStartTime":"2021-01-01T00:00:00Z"}},{"id":"old","snippet":{"liveBroadcastContent":"none"}},{"id":"vod","snippet":{"liveBroadcastContent":"none"}}]}`))
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	source := NewYoutubeSource("key")
+	source.APIURL = server.URL
+
+	live, err := source.LiveStreams(context.Background(), []string{"UClive", "UCoffline", "UCbroken"})
+	if err != nil {
+		t.Fatal("failed retrieving streams: ", err)
+	}
+
+	stream := live["uclive"]
+	if stream == nil || stream.ID != "abc" || stream.DisplayName != "Live channel" || stream.URL != "https://www.youtube.com/watch?v=abc" || stream.StartedAt.IsZero() {
+		t.Errorf("unexpected stream: %#v", stream)
+	}
+
+	if _, ok := live["ucoffline"]; ok {
+		t.Error("offline channel should not be in the result")
+	}
+
+	// failed channels are included as nil so their current state is kept
+	if broken, ok := live["ucbroken"]; !ok || broken != nil {
+		t.Errorf("expected the broken channel to be skipped, got %#v", broken)
+	}
+
+	source.APIKey = "wrong"
+	if _, err := source.LiveStreams(context.Background(), []string{"UClive"}); err == nil {
+		t.Error("expected an error with a bad api key")
+	}
+}
+
+func TestPolledChannels(t *testing.T) {
+	c := &Config{
+		TwitchChannels:  "Foo, https://www.twitch.tv/bar\nfoo,,baz",
+		YoutubeChannels: "UCabc UCabc",
+	}
+
+	twitch := c.PolledChannels(twitchPlatform)
+	if len(twitch) != 3 || twitch[0] != "foo" || twitch[1] != "bar" || twitch[2] != "baz" {
+		t.Errorf("unexpected twitch channels: %v", twitch)
+	}
+
+	youtube := c.PolledChannels(youtubePlatform)
+	if len(youtube) != 1 || youtube[0] != "UCabc" {
+		t.Errorf("unexpected youtube channels: %v", youtube)
+	}
+}
@@ -3,14 +3,19 @@ package streaming
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
+	"sync"
 
 	"emperror.dev/errors"
 	"github.com/jonas747/discordgo"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/featureflags"
+	"github.com/mediocregopher/radix/v3"
 )
 
-type Plugin struct{}
+type Plugin struct {
+	stopBGWorker chan *sync.WaitGroup
+}
 
 func (p *Plugin) PluginInfo() *common.PluginInfo {
 	return &common.PluginInfo{
@@ -23,7 +28,9 @@ func (p *Plugin) PluginInfo() *common.PluginInfo {
 var logger = common.GetPluginLogger(&Plugin{})
 
 func RegisterPlugin() {
-	plugin := &Plugin{}
+	plugin := &Plugin{
+		stopBGWorker: make(chan *sync.WaitGroup),
+	}
 	common.RegisterPlugin(plugin)
 }
 
@@ -45,6 +52,16 @@ type Config struct {
 	// Match the game name or title against these to filter users out
 	GameRegex  string `json:"game_regex" schema:"game_regex" valid:"regex,2000"`
 	TitleRegex string `json:"title_regex" schema:"title_regex" valid:"regex,2000"`
+
+	// Channels polled directly from the streaming platforms, separated by commas or newlines,
+	// for streamers that hide their activity or aren't in the server
+	TwitchChannels  string `json:"twitch_channels" schema:"twitch_channels" valid:",500"`
+	YoutubeChannels string `json:"youtube_channels" schema:"youtube_channels" valid:",500"`
+
+	// Minutes before the same member is announced again, counted from when their stream ended
+	AnnounceCooldown int `json:"announce_cooldown" schema:"announce_cooldown" valid:"0,10080"`
+	// Edit the announcement to show that the stream ended
+	EditEndedAnnouncements bool `json:"edit_ended_announcements" schema:"edit_ended_announcements"`
 }
 
 type LegacyConfig struct {
@@ -65,6 +82,16 @@ type LegacyConfig struct {
 	// Match the game name or title against these to filter users out
 	GameRegex  string `json:"game_regex" schema:"game_regex" valid:"regex,2000"`
 	TitleRegex string `json:"title_regex" schema:"title_regex" valid:"regex,2000"`
+
+	// Channels polled directly from the streaming platforms, separated by commas or newlines,
+	// for streamers that hide their activity or aren't in the server
+	TwitchChannels  string `json:"twitch_channels" schema:"twitch_channels" valid:",500"`
+	YoutubeChannels string `json:"youtube_channels" schema:"youtube_channels" valid:",500"`
+
+	// Minutes before the same member is announced again, counted from when their stream ended
+	AnnounceCooldown int `json:"announce_cooldown" schema:"announce_cooldown" valid:"0,10080"`
+	// Edit the announcement to show that the stream ended
+	EditEndedAnnouncements bool `json:"edit_ended_announcements" schema:"edit_ended_announcements"`
 }
 
 func (c *Config) UnmarshalJSON(b []byte) error {
@@ -83,17 +110,67 @@ func (c *Config) UnmarshalJSON(b []byte) error {
 	c.TitleRegex = tmp.TitleRegex
 	c.Enabled = tmp.Enabled
 	c.AnnounceMessage = tmp.AnnounceMessage
+	c.TwitchChannels = tmp.TwitchChannels
+	c.YoutubeChannels = tmp.YoutubeChannels
+	c.AnnounceCooldown = tmp.AnnounceCooldown
+	c.EditEndedAnnouncements = tmp.EditEndedAnnouncements
 
 	return nil
 }
 
 func (c *Config) Save(guildID int64) error {
-	return common.SetRedisJson("streaming_config:"+discordgo.StrID(guildID), c)
+	err := common.SetRedisJson("streaming_config:"+discordgo.StrID(guildID), c)
+	if err != nil {
+		return err
+	}
+
+	// keep track of the guilds with polled channels so the poller doesn't have to go through every config
+	cmd := "SREM"
+	if c.Enabled && c.AnnounceChannel != 0 && (c.TwitchChannels != "" || c.YoutubeChannels != "") {
+		cmd = "SADD"
+	}
+
+	return common.RedisPool.Do(radix.FlatCmd(nil, cmd, KeyPolledGuilds, guildID))
+}
+
+// PolledChannels returns the polled channels of the platform
+func (c *Config) PolledChannels(platform string) []string {
+	s := c.TwitchChannels
+	if platform == youtubePlatform {
+		s = c.YoutubeChannels
+	}
+
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' '
+	})
+
+	result := make([]string, 0, len(fields))
+	for _, v := range fields {
+		v = strings.TrimSpace(v)
+		if platform == twitchPlatform {
+			// allow full channel urls
+			v = strings.TrimPrefix(v, "https://")
+			v = strings.TrimPrefix(v, "www.")
+			v = strings.ToLower(strings.TrimPrefix(v, "twitch.tv/"))
+		}
+
+		if v == "" || common.ContainsStringSlice(result, v) {
+			continue
+		}
+
+		result = append(result, v)
+		if len(result) >= MaxPolledChannels {
+			break
+		}
+	}
+
+	return result
 }
 
 var DefaultConfig = &Config{
-	Enabled:         false,
-	AnnounceMessage: "OH WOWIE! **{{.User.Username}}** is currently streaming! Check it out: {{.URL}}",
+	Enabled:          false,
+	AnnounceMessage:  "OH WOWIE! **{{.User.Username}}** is currently streaming! Check it out: {{.URL}}",
+	AnnounceCooldown: DefaultAnnounceCooldown,
 }
 
 // Returns he guild's conifg, or the defaul one if not set
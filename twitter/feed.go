@@ -192,7 +192,7 @@ OUTER:
 	for _, v := range relevantFeeds {
 		go analytics.RecordActiveUnit(v.GuildID, p, "posted_twitter_message")
 
-		mqueue.QueueMessage(&mqueue.QueuedElement{
+		elem := &mqueue.QueuedElement{
 			Source:   "twitter",
 			SourceID: strconv.FormatInt(v.ID, 10),
 
@@ -204,7 +204,25 @@ OUTER:
 			WebhookUsername: webhookUsername,
 
 			Priority: 5, // above youtube and reddit
-		})
+		}
+
+		if v.MessageTemplate != "" {
+			custom, err := feeds.ExecuteMessageTemplate(v.GuildID, v.ChannelID, "twitter_feed", v.MessageTemplate, TemplateData(t))
+			if err == feeds.ErrEmptyTemplateOutput {
+				continue
+			}
+
+			if err != nil {
+				// post the standard embed instead
+				logger.WithError(err).WithField("guild", v.GuildID).Warn("failed executing twitter feed message template")
+			} else {
+				elem.MessageStr = custom.Content
+				elem.MessageEmbed = custom.Embed
+				elem.AllowedMentions = custom.AllowedMentions
+			}
+		}
+
+		mqueue.QueueMessage(elem)
 	}
 
 	feeds.MetricPostedMessages.With(prometheus.Labels{"source": "twitter"}).Add(float64(len(relevantFeeds)))
@@ -218,51 +236,74 @@ func createTweetEmbed(tweet *twitter.Tweet) *discordgo.MessageEmbed {
 		timeStr = parsed.Format(time.RFC3339)
 	}
 
-	text := tweet.Text
-	if tweet.FullText != "" {
-		text = tweet.FullText
-	}
-	if tweet.ExtendedTweet != nil && tweet.ExtendedTweet.FullText != "" {
-		text = tweet.ExtendedTweet.FullText
-	}
-
 	embed := &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    "@" + tweet.User.ScreenName,
 			IconURL: tweet.User.ProfileImageURLHttps,
-			URL:     "https://twitter.com/" + tweet.User.ScreenName + "/status/" + tweet.IDStr,
+			URL:     tweetURL(tweet),
 		},
-		Description: text,
+		Description: tweetText(tweet),
 		Timestamp:   timeStr,
 		Color:       0x38A1F3,
 	}
 
-	if tweet.Entities != nil && len(tweet.Entities.Media) > 0 {
-		m := tweet.Entities.Media[0]
-		if m.Type == "photo" || m.Type == "animated_gif" {
-			embed.Image = &discordgo.MessageEmbedImage{
-				URL: m.MediaURLHttps,
-			}
-		}
-	} else if tweet.ExtendedTweet != nil && tweet.ExtendedTweet.Entities != nil && len(tweet.ExtendedTweet.Entities.Media) > 0 {
-		m := tweet.ExtendedTweet.Entities.Media[0]
-		if m.Type == "photo" || m.Type == "animated_gif" {
-			embed.Image = &discordgo.MessageEmbedImage{
-				URL: m.MediaURLHttps,
-			}
-		}
-	} else if embed.Image == nil && tweet.ExtendedEntities != nil && len(tweet.ExtendedEntities.Media) > 0 {
-		m := tweet.ExtendedEntities.Media[0]
-		if m.Type == "photo" || m.Type == "animated_gif" {
-			embed.Image = &discordgo.MessageEmbedImage{
-				URL: m.MediaURLHttps,
-			}
+	if image := tweetImage(tweet); image != "" {
+		embed.Image = &discordgo.MessageEmbedImage{
+			URL: image,
 		}
 	}
 
 	return embed
 }
 
+// TemplateData returns the data available in custom feed message templates
+func TemplateData(tweet *twitter.Tweet) map[string]interface{} {
+	return map[string]interface{}{
+		"Text":         tweetText(tweet),
+		"Author":       tweet.User.ScreenName,
+		"AuthorName":   tweet.User.Name,
+		"AuthorAvatar": tweet.User.ProfileImageURLHttps,
+		"URL":          tweetURL(tweet),
+		"Thumbnail":    tweetImage(tweet),
+		"IsRetweet":    tweet.RetweetedStatus != nil,
+		"IsReply":      tweet.InReplyToScreenName != "" || tweet.InReplyToStatusID != 0 || tweet.InReplyToUserID != 0,
+	}
+}
+
+func tweetURL(tweet *twitter.Tweet) string {
+	return "https://twitter.com/" + tweet.User.ScreenName + "/status/" + tweet.IDStr
+}
+
+func tweetText(tweet *twitter.Tweet) string {
+	text := tweet.Text
+	if tweet.FullText != "" {
+		text = tweet.FullText
+	}
+	if tweet.ExtendedTweet != nil && tweet.ExtendedTweet.FullText != "" {
+		text = tweet.ExtendedTweet.FullText
+	}
+
+	return text
+}
+
+// tweetImage returns the url to the first photo or gif in the tweet, if any
+func tweetImage(tweet *twitter.Tweet) string {
+	var media []twitter.MediaEntity
+	if tweet.Entities != nil && len(tweet.Entities.Media) > 0 {
+		media = tweet.Entities.Media
+	} else if tweet.ExtendedTweet != nil && tweet.ExtendedTweet.Entities != nil && len(tweet.ExtendedTweet.Entities.Media) > 0 {
+		media = tweet.ExtendedTweet.Entities.Media
+	} else if tweet.ExtendedEntities != nil && len(tweet.ExtendedEntities.Media) > 0 {
+		media = tweet.ExtendedEntities.Media
+	}
+
+	if len(media) > 0 && (media[0].Type == "photo" || media[0].Type == "animated_gif") {
+		return media[0].MediaURLHttps
+	}
+
+	return ""
+}
+
 func feedsChanged(a, b []*models.TwitterFeed) bool {
 	if len(a) != len(b) {
 		return true
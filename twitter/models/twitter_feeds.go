@@ -32,6 +32,7 @@ type TwitterFeed struct {
 	Enabled         bool      `boil:"enabled" json:"enabled" toml:"enabled" yaml:"enabled"`
 	IncludeReplies  bool      `boil:"include_replies" json:"include_replies" toml:"include_replies" yaml:"include_replies"`
 	IncludeRT       bool      `boil:"include_rt" json:"include_rt" toml:"include_rt" yaml:"include_rt"`
+	MessageTemplate string    `boil:"message_template" json:"message_template" toml:"message_template" yaml:"message_template"`
 
 	R *twitterFeedR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L twitterFeedL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -47,6 +48,7 @@ var TwitterFeedColumns = struct {
 	Enabled         string
 	IncludeReplies  string
 	IncludeRT       string
+	MessageTemplate string
 }{
 	ID:              "id",
 	GuildID:         "guild_id",
@@ -57,6 +59,7 @@ var TwitterFeedColumns = struct {
 	Enabled:         "enabled",
 	IncludeReplies:  "include_replies",
 	IncludeRT:       "include_rt",
+	MessageTemplate: "message_template",
 }
 
 // Generated where
@@ -126,6 +129,7 @@ var TwitterFeedWhere = struct {
 	Enabled         whereHelperbool
 	IncludeReplies  whereHelperbool
 	IncludeRT       whereHelperbool
+	MessageTemplate whereHelperstring
 }{
 	ID:              whereHelperint64{field: "\"twitter_feeds\".\"id\""},
 	GuildID:         whereHelperint64{field: "\"twitter_feeds\".\"guild_id\""},
@@ -136,6 +140,7 @@ var TwitterFeedWhere = struct {
 	Enabled:         whereHelperbool{field: "\"twitter_feeds\".\"enabled\""},
 	IncludeReplies:  whereHelperbool{field: "\"twitter_feeds\".\"include_replies\""},
 	IncludeRT:       whereHelperbool{field: "\"twitter_feeds\".\"include_rt\""},
+	MessageTemplate: whereHelperstring{field: "\"twitter_feeds\".\"message_template\""},
 }
 
 // TwitterFeedRels is where relationship names are stored.
@@ -155,9 +160,9 @@ func (*twitterFeedR) NewStruct() *twitterFeedR {
 type twitterFeedL struct{}
 
 var (
-	twitterFeedAllColumns            = []string{"id", "guild_id", "created_at", "twitter_username", "twitter_user_id", "channel_id", "enabled", "include_replies", "include_rt"}
+	twitterFeedAllColumns            = []string{"id", "guild_id", "created_at", "twitter_username", "twitter_user_id", "channel_id", "enabled", "include_replies", "include_rt", "message_template"}
 	twitterFeedColumnsWithoutDefault = []string{"guild_id", "created_at", "twitter_username", "twitter_user_id", "channel_id", "enabled"}
-	twitterFeedColumnsWithDefault    = []string{"id", "include_replies", "include_rt"}
+	twitterFeedColumnsWithDefault    = []string{"id", "include_replies", "include_rt", "message_template"}
 	twitterFeedPrimaryKeyColumns     = []string{"id"}
 )
 
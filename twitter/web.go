@@ -6,10 +6,12 @@ import (
 	"html/template"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/jonas747/go-twitter/twitter"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/cplogs"
+	"github.com/jonas747/yagpdb/feeds"
 	"github.com/jonas747/yagpdb/premium"
 	"github.com/jonas747/yagpdb/twitter/models"
 	"github.com/jonas747/yagpdb/web"
@@ -26,15 +28,17 @@ const (
 )
 
 type Form struct {
-	TwitterUser    string `valid:",1,256"`
-	DiscordChannel int64  `valid:"channel,false"`
-	ID             int64
+	TwitterUser     string `valid:",1,256"`
+	DiscordChannel  int64  `valid:"channel,false"`
+	ID              int64
+	MessageTemplate string
 }
 
 type EditForm struct {
 	DiscordChannel  int64 `valid:"channel,false"`
 	IncludeReplies  bool
 	IncludeRetweets bool
+	MessageTemplate string
 }
 
 var (
@@ -113,6 +117,11 @@ func (p *Plugin) HandleNew(w http.ResponseWriter, r *http.Request) (web.Template
 
 	form := ctx.Value(common.ContextKeyParsedForm).(*Form)
 
+	messageTemplate := strings.TrimSpace(form.MessageTemplate)
+	if err := feeds.ValidateMessageTemplate(messageTemplate); err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid message template: " + err.Error())), nil
+	}
+
 	// search up the ID
 	users, _, err := p.twitterAPI.Users.Lookup(&twitter.UserLookupParams{
 		ScreenName: []string{form.TwitterUser},
@@ -138,6 +147,7 @@ func (p *Plugin) HandleNew(w http.ResponseWriter, r *http.Request) (web.Template
 		TwitterUserID:   user.ID,
 		ChannelID:       form.DiscordChannel,
 		Enabled:         true,
+		MessageTemplate: messageTemplate,
 	}
 
 	err = m.InsertG(ctx, boil.Infer())
@@ -187,12 +197,18 @@ func (p *Plugin) HandleEdit(w http.ResponseWriter, r *http.Request) (templateDat
 	sub := ctx.Value(ContextKeySub).(*models.TwitterFeed)
 	data := ctx.Value(common.ContextKeyParsedForm).(*EditForm)
 
+	messageTemplate := strings.TrimSpace(data.MessageTemplate)
+	if err := feeds.ValidateMessageTemplate(messageTemplate); err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid message template: " + err.Error())), nil
+	}
+
 	sub.ChannelID = data.DiscordChannel
 	sub.Enabled = true
 	sub.IncludeRT = data.IncludeRetweets
 	sub.IncludeReplies = data.IncludeReplies
+	sub.MessageTemplate = messageTemplate
 
-	_, err = sub.UpdateG(ctx, boil.Whitelist("channel_id", "enabled", "include_replies", "include_rt"))
+	_, err = sub.UpdateG(ctx, boil.Whitelist("channel_id", "enabled", "include_replies", "include_rt", "message_template"))
 	if err == nil {
 		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKeyUpdatedFeed, &cplogs.Param{Type: cplogs.ParamTypeString, Value: sub.TwitterUsername}))
 	}
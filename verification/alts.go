@@ -0,0 +1,158 @@
+package verification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// AltActionKick kicks users with a risk score above the threshold
+	AltActionKick = "kick"
+	// AltActionBan bans users with a risk score above the threshold
+	AltActionBan = "ban"
+
+	// how similar a username has to be to a banned users name to count
+	altNameSimilarityThreshold = 0.8
+
+	// how long after a ban a join is considered suspicious
+	altJoinAfterBanWindow = time.Hour
+
+	maxRiskScore = 100
+)
+
+// AltRiskFactors are the signals used to estimate how likely it is that a user is an alt account
+type AltRiskFactors struct {
+	AccountAge time.Duration
+
+	// number of other verified users sharing the ip, and whether one of them is banned
+	SharedIPUsers      int
+	SharedIPWithBanned bool
+
+	// names of banned users with the exact same avatar (same avatar hash) or a similar username
+	SameAvatarAsBanned string
+	SimilarBannedName  string
+	NameSimilarity     float64
+
+	// time between the ban of the most recently banned related user (same avatar or similar username) and the user joining,
+	// 0 if there was none within altJoinAfterBanWindow
+	JoinedAfterBan time.Duration
+}
+
+// Score returns the risk score between 0 and 100 and the reasons contributing to it
+func (f *AltRiskFactors) Score() (score int, reasons []string) {
+	add := func(points int, reason string) {
+		score += points
+		reasons = append(reasons, fmt.Sprintf("+%d %s", points, reason))
+	}
+
+	if f.SharedIPWithBanned {
+		add(50, "shares IP with a banned user")
+	}
+
+	if f.SharedIPUsers > 0 {
+		// school and other shared networks give a lot of these, so keep the weight low
+		points := f.SharedIPUsers * 5
+		if points > 20 {
+			points = 20
+		}
+		add(points, fmt.Sprintf("shares IP with %d other verified user(s)", f.SharedIPUsers))
+	}
+
+	switch {
+	case f.AccountAge < time.Hour*24:
+		add(30, "account is less than a day old")
+	case f.AccountAge < time.Hour*24*7:
+		add(20, "account is less than a week old")
+	case f.AccountAge < time.Hour*24*30:
+		add(10, "account is less than a month old")
+	}
+
+	if f.SameAvatarAsBanned != "" {
+		add(40, "same avatar as banned user "+f.SameAvatarAsBanned)
+	}
+
+	if f.SimilarBannedName != "" && f.NameSimilarity >= altNameSimilarityThreshold {
+		add(int(f.NameSimilarity*40), fmt.Sprintf("username %d%% similar to banned user %s", int(f.NameSimilarity*100), f.SimilarBannedName))
+	}
+
+	// joining shortly after an unrelated ban says nothing on its own, so this only adds to the signals tying the user to a banned one
+	if f.JoinedAfterBan > 0 && f.relatedToBanned() {
+		if f.JoinedAfterBan < time.Minute*10 {
+			add(30, "joined within 10 minutes of a ban")
+		} else {
+			add(20, "joined within an hour of a ban")
+		}
+	}
+
+	if score > maxRiskScore {
+		score = maxRiskScore
+	}
+
+	return score, reasons
+}
+
+// relatedToBanned returns true if the avatar or username ties the user to a banned user
+func (f *AltRiskFactors) relatedToBanned() bool {
+	return f.SameAvatarAsBanned != "" || (f.SimilarBannedName != "" && f.NameSimilarity >= altNameSimilarityThreshold)
+}
+
+// NameSimilarity returns how similar 2 usernames are between 0 and 1, ignoring case and non alphanumeric characters
+func NameSimilarity(a, b string) float64 {
+	ra := normalizeName(a)
+	rb := normalizeName(b)
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+
+	if longest == 0 {
+		return 0
+	}
+
+	return float64(longest-levenshteinDistance(ra, rb)) / float64(longest)
+}
+
+func normalizeName(s string) []rune {
+	result := make([]rune, 0, len(s))
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+func levenshteinDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+package verification
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNameSimilarity(t *testing.T) {
+	cases := []struct {
+		A, B string
+		Min  float64
+		Max  float64
+	}{
+		{"evilguy", "EvilGuy", 1, 1},
+		{"evil_guy", "evilguy2", 0.85, 0.9},
+		{"jonas", "completelydifferent", 0, 0.3},
+		{"", "", 0, 0},
+	}
+
+	for _, c := range cases {
+		s := NameSimilarity(c.A, c.B)
+		if s < c.Min || s > c.Max {
+			t.Errorf("NameSimilarity(%q, %q) = %f, expected between %f and %f", c.A, c.B, s, c.Min, c.Max)
+		}
+	}
+}
+
+func TestAltRiskScore(t *testing.T) {
+	old := &AltRiskFactors{AccountAge: time.Hour * 24 * 365}
+	if score, reasons := old.Score(); score != 0 || len(reasons) != 0 {
+		t.Errorf("expected old account without signals to score 0, got %d: %v", score, reasons)
+	}
+
+	schoolNetwork := &AltRiskFactors{AccountAge: time.Hour * 24 * 365, SharedIPUsers: 50}
+	if score, _ := schoolNetwork.Score(); score != 20 {
+		t.Errorf("expected many shared ip's to be capped at 20, got %d", score)
+	}
+
+	unrelatedBan := &AltRiskFactors{AccountAge: time.Minute, JoinedAfterBan: time.Minute * 2}
+	if score, reasons := unrelatedBan.Score(); score != 30 || len(reasons) != 1 {
+		t.Errorf("expected joining after an unrelated ban to not add to the score, got %d: %v", score, reasons)
+	}
+
+	evader := &AltRiskFactors{
+		AccountAge:         time.Minute,
+		SameAvatarAsBanned: "evilguy",
+		SimilarBannedName:  "evilguy",
+		NameSimilarity:     0.9,
+		JoinedAfterBan:     time.Minute * 2,
+	}
+	if score, reasons := evader.Score(); score != maxRiskScore || len(reasons) != 4 {
+		t.Errorf("expected ban evader to get the max score with 4 reasons, got %d: %v", score, reasons)
+	}
+}
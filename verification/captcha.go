@@ -0,0 +1,127 @@
+package verification
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"strconv"
+)
+
+// captchaGlyphs is a tiny 5x7 bitmap font covering the characters used in the math captcha
+var captchaGlyphs = map[rune][7]string{
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'+': {".....", "..#..", "..#..", "#####", "..#..", "..#..", "....."},
+	'-': {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	'=': {".....", ".....", "#####", ".....", "#####", ".....", "....."},
+	'?': {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."},
+}
+
+const (
+	captchaGlyphScale   = 5
+	captchaGlyphSpacing = 2
+	captchaPadding      = 12
+)
+
+// GenerateMathProblem returns an addition or subtraction problem of two 2 digit numbers and its answer,
+// the answers range from 0 to 198 so that they're not easily guessed
+func GenerateMathProblem() (question string, answer string) {
+	a := rand.Intn(90) + 10
+	b := rand.Intn(90) + 10
+
+	if rand.Intn(2) == 0 {
+		return strconv.Itoa(a) + "+" + strconv.Itoa(b) + "=?", strconv.Itoa(a + b)
+	}
+
+	if b > a {
+		a, b = b, a
+	}
+
+	return strconv.Itoa(a) + "-" + strconv.Itoa(b) + "=?", strconv.Itoa(a - b)
+}
+
+// RenderCaptchaImage renders text into a noisy PNG image, characters not in the captcha font are skipped
+func RenderCaptchaImage(text string) ([]byte, error) {
+	glyphWidth := 5 * captchaGlyphScale
+	glyphHeight := 7 * captchaGlyphScale
+
+	runes := []rune(text)
+	width := captchaPadding*2 + len(runes)*(glyphWidth+captchaGlyphSpacing*captchaGlyphScale)
+	height := captchaPadding*2 + glyphHeight
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	// background with some per pixel noise
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			v := uint8(220 + rand.Intn(36))
+			img.Set(x, y, color.RGBA{v, v, v, 255})
+		}
+	}
+
+	// random noise lines behind the text
+	for i := 0; i < 6; i++ {
+		drawNoiseLine(img, randomDarkColor())
+	}
+
+	x := captchaPadding
+	for _, r := range runes {
+		glyph, ok := captchaGlyphs[r]
+		if !ok {
+			continue
+		}
+
+		c := randomDarkColor()
+		yOffset := captchaPadding + rand.Intn(7) - 3
+		for gy, row := range glyph {
+			for gx, px := range row {
+				if px != '#' {
+					continue
+				}
+
+				for sx := 0; sx < captchaGlyphScale; sx++ {
+					for sy := 0; sy < captchaGlyphScale; sy++ {
+						img.Set(x+gx*captchaGlyphScale+sx, yOffset+gy*captchaGlyphScale+sy, c)
+					}
+				}
+			}
+		}
+
+		x += glyphWidth + captchaGlyphSpacing*captchaGlyphScale
+	}
+
+	// and a couple in front of it
+	for i := 0; i < 3; i++ {
+		drawNoiseLine(img, randomDarkColor())
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	return buf.Bytes(), err
+}
+
+func randomDarkColor() color.RGBA {
+	return color.RGBA{uint8(rand.Intn(120)), uint8(rand.Intn(120)), uint8(rand.Intn(120)), 255}
+}
+
+func drawNoiseLine(img *image.RGBA, c color.RGBA) {
+	b := img.Bounds()
+	x0, y0 := 0, rand.Intn(b.Dy())
+	x1, y1 := b.Dx()-1, rand.Intn(b.Dy())
+
+	steps := x1 - x0
+	for i := 0; i <= steps; i++ {
+		y := y0 + (y1-y0)*i/steps
+		img.Set(x0+i, y, c)
+	}
+}
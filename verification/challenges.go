@@ -0,0 +1,188 @@
+package verification
+
+import (
+	"context"
+	"encoding/base64"
+	"html/template"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"github.com/jonas747/yagpdb/verification/models"
+	"github.com/jonas747/yagpdb/web"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+// ChallengeType is the kind of challenge users have to complete on the verification page
+type ChallengeType string
+
+const (
+	ChallengeTypeReCAPTCHA ChallengeType = "recaptcha"
+	ChallengeTypeCaptcha   ChallengeType = "captcha"
+	ChallengeTypeQuiz      ChallengeType = "quiz"
+)
+
+// Challenge is implemented by the verification challenge types
+type Challenge interface {
+	// Prepare sets up the challenge for the provided session, adding whatever the verify page needs to templateData
+	Prepare(ctx context.Context, templateData web.TemplateData, conf *models.VerificationConfig, session *models.VerificationSession) error
+
+	// Check returns true if the submitted form solves the challenge
+	Check(r *http.Request, conf *models.VerificationConfig, session *models.VerificationSession) (bool, error)
+}
+
+var challenges = map[ChallengeType]Challenge{
+	ChallengeTypeReCAPTCHA: &ReCAPTCHAChallenge{},
+	ChallengeTypeCaptcha:   &CaptchaChallenge{},
+	ChallengeTypeQuiz:      &QuizChallenge{},
+}
+
+func reCAPTCHAAvailable() bool {
+	return confGoogleReCAPTCHASecret.GetString() != "" && confGoogleReCAPTCHASiteKey.GetString() != ""
+}
+
+// ChallengeForConfig returns the challenge to use for the provided config, falling back to the self hosted captcha
+// if the configured one is unavailable
+func ChallengeForConfig(conf *models.VerificationConfig) (ChallengeType, Challenge) {
+	t := ChallengeType(conf.ChallengeType)
+	if t == "" {
+		t = ChallengeTypeReCAPTCHA
+	}
+
+	if t == ChallengeTypeReCAPTCHA && !reCAPTCHAAvailable() {
+		t = ChallengeTypeCaptcha
+	}
+
+	if t == ChallengeTypeQuiz && len(ParseQuizQuestions(conf.QuizQuestions)) < 1 {
+		t = ChallengeTypeCaptcha
+	}
+
+	c, ok := challenges[t]
+	if !ok {
+		return ChallengeTypeCaptcha, challenges[ChallengeTypeCaptcha]
+	}
+
+	return t, c
+}
+
+// ReCAPTCHAChallenge uses google's reCAPTCHA
+type ReCAPTCHAChallenge struct{}
+
+func (c *ReCAPTCHAChallenge) Prepare(ctx context.Context, templateData web.TemplateData, conf *models.VerificationConfig, session *models.VerificationSession) error {
+	templateData["ExtraHead"] = template.HTML(`<script src="https://www.google.com/recaptcha/api.js" async defer></script>`)
+	templateData["GoogleReCaptchaSiteKey"] = confGoogleReCAPTCHASiteKey.GetString()
+	return nil
+}
+
+func (c *ReCAPTCHAChallenge) Check(r *http.Request, conf *models.VerificationConfig, session *models.VerificationSession) (bool, error) {
+	return checkCAPTCHAResponse(r.FormValue("g-recaptcha-response"))
+}
+
+// CaptchaChallenge is a self hosted image captcha asking the user to solve a simple math problem
+type CaptchaChallenge struct{}
+
+func (c *CaptchaChallenge) Prepare(ctx context.Context, templateData web.TemplateData, conf *models.VerificationConfig, session *models.VerificationSession) error {
+	question, answer := GenerateMathProblem()
+
+	img, err := RenderCaptchaImage(question)
+	if err != nil {
+		return err
+	}
+
+	session.ChallengeData = answer
+	_, err = session.UpdateG(ctx, boil.Whitelist("challenge_data"))
+	if err != nil {
+		return err
+	}
+
+	templateData["CaptchaImage"] = template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(img))
+	return nil
+}
+
+func (c *CaptchaChallenge) Check(r *http.Request, conf *models.VerificationConfig, session *models.VerificationSession) (bool, error) {
+	if session.ChallengeData == "" {
+		return false, nil
+	}
+
+	return strings.TrimSpace(r.FormValue("captcha_answer")) == session.ChallengeData, nil
+}
+
+// QuizQuestion is a server rules question with one or more accepted answers
+type QuizQuestion struct {
+	Question string
+	Answers  []string
+}
+
+// ParseQuizQuestions parses questions in the "Question | answer 1; answer 2" format, one per line
+func ParseQuizQuestions(s string) []*QuizQuestion {
+	var result []*QuizQuestion
+
+	for _, line := range strings.Split(s, "\n") {
+		split := strings.SplitN(line, "|", 2)
+		if len(split) < 2 {
+			continue
+		}
+
+		q := &QuizQuestion{
+			Question: strings.TrimSpace(split[0]),
+		}
+
+		for _, a := range strings.Split(split[1], ";") {
+			a = strings.TrimSpace(a)
+			if a != "" {
+				q.Answers = append(q.Answers, a)
+			}
+		}
+
+		if q.Question == "" || len(q.Answers) < 1 {
+			continue
+		}
+
+		result = append(result, q)
+	}
+
+	return result
+}
+
+// IsCorrect returns true if answer matches one of the accepted answers, ignoring case and surrounding whitespace
+func (q *QuizQuestion) IsCorrect(answer string) bool {
+	answer = strings.TrimSpace(answer)
+	for _, v := range q.Answers {
+		if strings.EqualFold(v, answer) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// QuizChallenge asks the user a random question about the server rules
+type QuizChallenge struct{}
+
+func (c *QuizChallenge) Prepare(ctx context.Context, templateData web.TemplateData, conf *models.VerificationConfig, session *models.VerificationSession) error {
+	questions := ParseQuizQuestions(conf.QuizQuestions)
+	if len(questions) < 1 {
+		return nil
+	}
+
+	q := questions[rand.Intn(len(questions))]
+
+	session.ChallengeData = q.Question
+	_, err := session.UpdateG(ctx, boil.Whitelist("challenge_data"))
+	if err != nil {
+		return err
+	}
+
+	templateData["QuizQuestion"] = q.Question
+	return nil
+}
+
+func (c *QuizChallenge) Check(r *http.Request, conf *models.VerificationConfig, session *models.VerificationSession) (bool, error) {
+	for _, q := range ParseQuizQuestions(conf.QuizQuestions) {
+		if q.Question == session.ChallengeData {
+			return q.IsCorrect(r.FormValue("quiz_answer")), nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,84 @@
+package verification
+
+import (
+	"bytes"
+	"image/png"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseQuizQuestions(t *testing.T) {
+	input := `What channel do you post memes in? | memes; #memes
+this line is invalid
+   Is spamming allowed?   |   no
+No answers |  ;  `
+
+	questions := ParseQuizQuestions(input)
+	if len(questions) != 2 {
+		t.Fatalf("expected 2 questions, got %d", len(questions))
+	}
+
+	if questions[0].Question != "What channel do you post memes in?" {
+		t.Errorf("unexpected question: %q", questions[0].Question)
+	}
+
+	if len(questions[0].Answers) != 2 || questions[0].Answers[1] != "#memes" {
+		t.Errorf("unexpected answers: %#v", questions[0].Answers)
+	}
+
+	if !questions[1].IsCorrect(" NO ") {
+		t.Error("answer should have matched ignoring case and whitespace")
+	}
+
+	if questions[1].IsCorrect("yes") {
+		t.Error("wrong answer should not have matched")
+	}
+}
+
+func TestGenerateMathProblem(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		question, answer := GenerateMathProblem()
+
+		expr := strings.TrimSuffix(question, "=?")
+		var a, b, expected int
+		if split := strings.SplitN(expr, "+", 2); len(split) == 2 {
+			a, _ = strconv.Atoi(split[0])
+			b, _ = strconv.Atoi(split[1])
+			expected = a + b
+		} else {
+			split = strings.SplitN(expr, "-", 2)
+			a, _ = strconv.Atoi(split[0])
+			b, _ = strconv.Atoi(split[1])
+			expected = a - b
+		}
+
+		if strconv.Itoa(expected) != answer {
+			t.Fatalf("%s: expected %d, got %s", question, expected, answer)
+		}
+
+		if expected < 0 {
+			t.Fatalf("%s: negative answer", question)
+		}
+
+		if a < 10 || a > 99 || b < 10 || b > 99 {
+			t.Fatalf("%s: expected 2 digit numbers", question)
+		}
+	}
+}
+
+func TestRenderCaptchaImage(t *testing.T) {
+	img, err := RenderCaptchaImage("12+3=?")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := png.Decode(bytes.NewReader(img))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Bounds().Dx() <= decoded.Bounds().Dy() {
+		t.Errorf("unexpected image dimensions: %v", decoded.Bounds())
+	}
+}
@@ -4,11 +4,13 @@
 package models
 
 var TableNames = struct {
-	VerificationConfigs  string
-	VerificationSessions string
-	VerifiedUsers        string
+	VerificationBannedUsers string
+	VerificationConfigs     string
+	VerificationSessions    string
+	VerifiedUsers           string
 }{
-	VerificationConfigs:  "verification_configs",
-	VerificationSessions: "verification_sessions",
-	VerifiedUsers:        "verified_users",
+	VerificationBannedUsers: "verification_banned_users",
+	VerificationConfigs:     "verification_configs",
+	VerificationSessions:    "verification_sessions",
+	VerifiedUsers:           "verified_users",
 }
@@ -32,6 +32,10 @@ type VerificationConfig struct {
 	WarnMessage         string `boil:"warn_message" json:"warn_message" toml:"warn_message" yaml:"warn_message"`
 	LogChannel          int64  `boil:"log_channel" json:"log_channel" toml:"log_channel" yaml:"log_channel"`
 	DMMessage           string `boil:"dm_message" json:"dm_message" toml:"dm_message" yaml:"dm_message"`
+	ChallengeType       string `boil:"challenge_type" json:"challenge_type" toml:"challenge_type" yaml:"challenge_type"`
+	QuizQuestions       string `boil:"quiz_questions" json:"quiz_questions" toml:"quiz_questions" yaml:"quiz_questions"`
+	AltActionThreshold  int    `boil:"alt_action_threshold" json:"alt_action_threshold" toml:"alt_action_threshold" yaml:"alt_action_threshold"`
+	AltAction           string `boil:"alt_action" json:"alt_action" toml:"alt_action" yaml:"alt_action"`
 
 	R *verificationConfigR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L verificationConfigL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -47,6 +51,10 @@ var VerificationConfigColumns = struct {
 	WarnMessage         string
 	LogChannel          string
 	DMMessage           string
+	ChallengeType       string
+	QuizQuestions       string
+	AltActionThreshold  string
+	AltAction           string
 }{
 	GuildID:             "guild_id",
 	Enabled:             "enabled",
@@ -57,6 +65,10 @@ var VerificationConfigColumns = struct {
 	WarnMessage:         "warn_message",
 	LogChannel:          "log_channel",
 	DMMessage:           "dm_message",
+	ChallengeType:       "challenge_type",
+	QuizQuestions:       "quiz_questions",
+	AltActionThreshold:  "alt_action_threshold",
+	AltAction:           "alt_action",
 }
 
 // Generated where
@@ -107,6 +119,10 @@ var VerificationConfigWhere = struct {
 	WarnMessage         whereHelperstring
 	LogChannel          whereHelperint64
 	DMMessage           whereHelperstring
+	ChallengeType       whereHelperstring
+	QuizQuestions       whereHelperstring
+	AltActionThreshold  whereHelperint
+	AltAction           whereHelperstring
 }{
 	GuildID:             whereHelperint64{field: "\"verification_configs\".\"guild_id\""},
 	Enabled:             whereHelperbool{field: "\"verification_configs\".\"enabled\""},
@@ -117,6 +133,10 @@ var VerificationConfigWhere = struct {
 	WarnMessage:         whereHelperstring{field: "\"verification_configs\".\"warn_message\""},
 	LogChannel:          whereHelperint64{field: "\"verification_configs\".\"log_channel\""},
 	DMMessage:           whereHelperstring{field: "\"verification_configs\".\"dm_message\""},
+	ChallengeType:       whereHelperstring{field: "\"verification_configs\".\"challenge_type\""},
+	QuizQuestions:       whereHelperstring{field: "\"verification_configs\".\"quiz_questions\""},
+	AltActionThreshold:  whereHelperint{field: "\"verification_configs\".\"alt_action_threshold\""},
+	AltAction:           whereHelperstring{field: "\"verification_configs\".\"alt_action\""},
 }
 
 // VerificationConfigRels is where relationship names are stored.
@@ -136,9 +156,9 @@ func (*verificationConfigR) NewStruct() *verificationConfigR {
 type verificationConfigL struct{}
 
 var (
-	verificationConfigAllColumns            = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message"}
+	verificationConfigAllColumns            = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message", "challenge_type", "quiz_questions", "alt_action_threshold", "alt_action"}
 	verificationConfigColumnsWithoutDefault = []string{"guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel"}
-	verificationConfigColumnsWithDefault    = []string{"dm_message"}
+	verificationConfigColumnsWithDefault    = []string{"dm_message", "challenge_type", "quiz_questions", "alt_action_threshold", "alt_action"}
 	verificationConfigPrimaryKeyColumns     = []string{"guild_id"}
 )
 
@@ -24,31 +24,37 @@ import (
 
 // VerificationSession is an object representing the database table.
 type VerificationSession struct {
-	Token     string    `boil:"token" json:"token" toml:"token" yaml:"token"`
-	UserID    int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	GuildID   int64     `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	SolvedAt  null.Time `boil:"solved_at" json:"solved_at,omitempty" toml:"solved_at" yaml:"solved_at,omitempty"`
-	ExpiredAt null.Time `boil:"expired_at" json:"expired_at,omitempty" toml:"expired_at" yaml:"expired_at,omitempty"`
+	Token          string    `boil:"token" json:"token" toml:"token" yaml:"token"`
+	UserID         int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	GuildID        int64     `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
+	CreatedAt      time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	SolvedAt       null.Time `boil:"solved_at" json:"solved_at,omitempty" toml:"solved_at" yaml:"solved_at,omitempty"`
+	ExpiredAt      null.Time `boil:"expired_at" json:"expired_at,omitempty" toml:"expired_at" yaml:"expired_at,omitempty"`
+	ChallengeData  string    `boil:"challenge_data" json:"challenge_data" toml:"challenge_data" yaml:"challenge_data"`
+	FailedAttempts int       `boil:"failed_attempts" json:"failed_attempts" toml:"failed_attempts" yaml:"failed_attempts"`
 
 	R *verificationSessionR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L verificationSessionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var VerificationSessionColumns = struct {
-	Token     string
-	UserID    string
-	GuildID   string
-	CreatedAt string
-	SolvedAt  string
-	ExpiredAt string
+	Token          string
+	UserID         string
+	GuildID        string
+	CreatedAt      string
+	SolvedAt       string
+	ExpiredAt      string
+	ChallengeData  string
+	FailedAttempts string
 }{
-	Token:     "token",
-	UserID:    "user_id",
-	GuildID:   "guild_id",
-	CreatedAt: "created_at",
-	SolvedAt:  "solved_at",
-	ExpiredAt: "expired_at",
+	Token:          "token",
+	UserID:         "user_id",
+	GuildID:        "guild_id",
+	CreatedAt:      "created_at",
+	SolvedAt:       "solved_at",
+	ExpiredAt:      "expired_at",
+	ChallengeData:  "challenge_data",
+	FailedAttempts: "failed_attempts",
 }
 
 // Generated where
@@ -98,19 +104,23 @@ func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 }
 
 var VerificationSessionWhere = struct {
-	Token     whereHelperstring
-	UserID    whereHelperint64
-	GuildID   whereHelperint64
-	CreatedAt whereHelpertime_Time
-	SolvedAt  whereHelpernull_Time
-	ExpiredAt whereHelpernull_Time
+	Token          whereHelperstring
+	UserID         whereHelperint64
+	GuildID        whereHelperint64
+	CreatedAt      whereHelpertime_Time
+	SolvedAt       whereHelpernull_Time
+	ExpiredAt      whereHelpernull_Time
+	ChallengeData  whereHelperstring
+	FailedAttempts whereHelperint
 }{
-	Token:     whereHelperstring{field: "\"verification_sessions\".\"token\""},
-	UserID:    whereHelperint64{field: "\"verification_sessions\".\"user_id\""},
-	GuildID:   whereHelperint64{field: "\"verification_sessions\".\"guild_id\""},
-	CreatedAt: whereHelpertime_Time{field: "\"verification_sessions\".\"created_at\""},
-	SolvedAt:  whereHelpernull_Time{field: "\"verification_sessions\".\"solved_at\""},
-	ExpiredAt: whereHelpernull_Time{field: "\"verification_sessions\".\"expired_at\""},
+	Token:          whereHelperstring{field: "\"verification_sessions\".\"token\""},
+	UserID:         whereHelperint64{field: "\"verification_sessions\".\"user_id\""},
+	GuildID:        whereHelperint64{field: "\"verification_sessions\".\"guild_id\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"verification_sessions\".\"created_at\""},
+	SolvedAt:       whereHelpernull_Time{field: "\"verification_sessions\".\"solved_at\""},
+	ExpiredAt:      whereHelpernull_Time{field: "\"verification_sessions\".\"expired_at\""},
+	ChallengeData:  whereHelperstring{field: "\"verification_sessions\".\"challenge_data\""},
+	FailedAttempts: whereHelperint{field: "\"verification_sessions\".\"failed_attempts\""},
 }
 
 // VerificationSessionRels is where relationship names are stored.
@@ -130,9 +140,9 @@ func (*verificationSessionR) NewStruct() *verificationSessionR {
 type verificationSessionL struct{}
 
 var (
-	verificationSessionAllColumns            = []string{"token", "user_id", "guild_id", "created_at", "solved_at", "expired_at"}
+	verificationSessionAllColumns            = []string{"token", "user_id", "guild_id", "created_at", "solved_at", "expired_at", "challenge_data", "failed_attempts"}
 	verificationSessionColumnsWithoutDefault = []string{"token", "user_id", "guild_id", "created_at", "solved_at", "expired_at"}
-	verificationSessionColumnsWithDefault    = []string{}
+	verificationSessionColumnsWithDefault    = []string{"challenge_data", "failed_attempts"}
 	verificationSessionPrimaryKeyColumns     = []string{"token"}
 )
 
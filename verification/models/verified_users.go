@@ -23,39 +23,49 @@ import (
 
 // VerifiedUser is an object representing the database table.
 type VerifiedUser struct {
-	GuildID    int64     `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
-	UserID     int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	VerifiedAt time.Time `boil:"verified_at" json:"verified_at" toml:"verified_at" yaml:"verified_at"`
-	IP         string    `boil:"ip" json:"ip" toml:"ip" yaml:"ip"`
+	GuildID     int64     `boil:"guild_id" json:"guild_id" toml:"guild_id" yaml:"guild_id"`
+	UserID      int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	VerifiedAt  time.Time `boil:"verified_at" json:"verified_at" toml:"verified_at" yaml:"verified_at"`
+	IP          string    `boil:"ip" json:"ip" toml:"ip" yaml:"ip"`
+	RiskScore   int       `boil:"risk_score" json:"risk_score" toml:"risk_score" yaml:"risk_score"`
+	RiskReasons string    `boil:"risk_reasons" json:"risk_reasons" toml:"risk_reasons" yaml:"risk_reasons"`
 
 	R *verifiedUserR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L verifiedUserL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var VerifiedUserColumns = struct {
-	GuildID    string
-	UserID     string
-	VerifiedAt string
-	IP         string
+	GuildID     string
+	UserID      string
+	VerifiedAt  string
+	IP          string
+	RiskScore   string
+	RiskReasons string
 }{
-	GuildID:    "guild_id",
-	UserID:     "user_id",
-	VerifiedAt: "verified_at",
-	IP:         "ip",
+	GuildID:     "guild_id",
+	UserID:      "user_id",
+	VerifiedAt:  "verified_at",
+	IP:          "ip",
+	RiskScore:   "risk_score",
+	RiskReasons: "risk_reasons",
 }
 
 // Generated where
 
 var VerifiedUserWhere = struct {
-	GuildID    whereHelperint64
-	UserID     whereHelperint64
-	VerifiedAt whereHelpertime_Time
-	IP         whereHelperstring
+	GuildID     whereHelperint64
+	UserID      whereHelperint64
+	VerifiedAt  whereHelpertime_Time
+	IP          whereHelperstring
+	RiskScore   whereHelperint
+	RiskReasons whereHelperstring
 }{
-	GuildID:    whereHelperint64{field: `guild_id`},
-	UserID:     whereHelperint64{field: `user_id`},
-	VerifiedAt: whereHelpertime_Time{field: `verified_at`},
-	IP:         whereHelperstring{field: `ip`},
+	GuildID:     whereHelperint64{field: `guild_id`},
+	UserID:      whereHelperint64{field: `user_id`},
+	VerifiedAt:  whereHelpertime_Time{field: `verified_at`},
+	IP:          whereHelperstring{field: `ip`},
+	RiskScore:   whereHelperint{field: `risk_score`},
+	RiskReasons: whereHelperstring{field: `risk_reasons`},
 }
 
 // VerifiedUserRels is where relationship names are stored.
@@ -75,9 +85,9 @@ func (*verifiedUserR) NewStruct() *verifiedUserR {
 type verifiedUserL struct{}
 
 var (
-	verifiedUserColumns               = []string{"guild_id", "user_id", "verified_at", "ip"}
+	verifiedUserColumns               = []string{"guild_id", "user_id", "verified_at", "ip", "risk_score", "risk_reasons"}
 	verifiedUserColumnsWithoutDefault = []string{"guild_id", "user_id", "verified_at", "ip"}
-	verifiedUserColumnsWithDefault    = []string{}
+	verifiedUserColumnsWithDefault    = []string{"risk_score", "risk_reasons"}
 	verifiedUserPrimaryKeyColumns     = []string{"guild_id", "user_id"}
 )
 
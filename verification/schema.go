@@ -38,4 +38,33 @@ CREATE TABLE IF NOT EXISTS verified_users (
 
 	PRIMARY KEY(guild_id, user_id)
 );
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS challenge_type TEXT NOT NULL DEFAULT 'recaptcha';
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS quiz_questions TEXT NOT NULL DEFAULT '';
+`, `
+ALTER TABLE verification_sessions ADD COLUMN IF NOT EXISTS challenge_data TEXT NOT NULL DEFAULT '';
+`, `
+ALTER TABLE verified_users ADD COLUMN IF NOT EXISTS risk_score INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE verified_users ADD COLUMN IF NOT EXISTS risk_reasons TEXT NOT NULL DEFAULT '';
+`, `
+CREATE TABLE IF NOT EXISTS verification_banned_users (
+	guild_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+
+	banned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	username TEXT NOT NULL,
+	avatar TEXT NOT NULL,
+
+	PRIMARY KEY(guild_id, user_id)
+);
+`, `
+CREATE INDEX IF NOT EXISTS verification_banned_users_guild_banned_at_idx ON verification_banned_users(guild_id, banned_at);
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS alt_action_threshold INT NOT NULL DEFAULT 0;
+`, `
+ALTER TABLE verification_configs ADD COLUMN IF NOT EXISTS alt_action TEXT NOT NULL DEFAULT '';
+`, `
+ALTER TABLE verification_sessions ADD COLUMN IF NOT EXISTS failed_attempts INT NOT NULL DEFAULT 0;
 `}
@@ -3,8 +3,16 @@ package verification
 //go:generate sqlboiler --no-hooks psql
 
 import (
+	"context"
+	"time"
+
+	"github.com/jonas747/yagpdb/analytics"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/config"
+	"github.com/jonas747/yagpdb/common/scheduledevents2"
+	"github.com/jonas747/yagpdb/verification/models"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
 )
 
 var confGoogleReCAPTCHASiteKey = config.RegisterOption("yagpdb.google.recaptcha_site_key", "Google reCAPTCHA site key", "")
@@ -25,9 +33,8 @@ var logger = common.GetPluginLogger(&Plugin{})
 
 func RegisterPlugin() {
 
-	if confGoogleReCAPTCHASecret.GetString() == "" || confGoogleReCAPTCHASiteKey.GetString() == "" {
-		logger.Warn("no YAGPDB_GOOGLE_RECAPTCHA_SECRET and/or YAGPDB_GOOGLE_RECAPTCHA_SITE_KEY provided, not enabling verification plugin")
-		return
+	if !reCAPTCHAAvailable() {
+		logger.Warn("no YAGPDB_GOOGLE_RECAPTCHA_SECRET and/or YAGPDB_GOOGLE_RECAPTCHA_SITE_KEY provided, the reCAPTCHA challenge will fall back to the self hosted captcha")
 	}
 
 	common.InitSchemas("verification", DBSchemas...)
@@ -38,10 +45,68 @@ func RegisterPlugin() {
 const (
 	DefaultPageContent = `## Verification
 
-Please solve the following reCAPTCHA to make sure you're not a robot`
+Please solve the following challenge to make sure you're not a robot`
 )
 
 const DefaultDMMessage = `{{sendMessage nil (cembed
 "title" "Are you a bot?"
 "description" (printf "Please solve the CAPTCHA at this link to make sure you're human, before you can enter %s: %s" .Server.Name .Link)
 )}}`
+
+// completeVerification marks the session as solved and schedules the verified event which gives the user the verified role,
+// ip is only stored if not empty so that previously tracked ips are kept for alt detection
+func (p *Plugin) completeVerification(ctx context.Context, session *models.VerificationSession, ip string) error {
+	model := &models.VerifiedUser{
+		UserID:     session.UserID,
+		GuildID:    session.GuildID,
+		VerifiedAt: time.Now(),
+		IP:         ip,
+	}
+
+	updateColumns := boil.Whitelist("verified_at", "ip")
+	if ip == "" {
+		updateColumns = boil.Whitelist("verified_at")
+	}
+
+	err := model.UpsertG(ctx, true, []string{"guild_id", "user_id"}, updateColumns, boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	session.SolvedAt = null.TimeFrom(time.Now())
+	_, err = session.UpdateG(ctx, boil.Whitelist("solved_at"))
+	if err != nil {
+		return err
+	}
+
+	err = scheduledevents2.ScheduleEvent("verification_user_verified", session.GuildID, time.Now(), session.UserID)
+	if err != nil {
+		return err
+	}
+
+	go analytics.RecordActiveUnit(session.GuildID, p, "completed")
+	return nil
+}
+
+// MaxFailedAttempts is how many times a user can fail the challenge before the session is expired
+const MaxFailedAttempts = 5
+
+// recordFailedAttempt counts a failed attempt at the challenge and clears it so that every challenge can only be answered once,
+// the session is expired once the user has failed MaxFailedAttempts times
+func recordFailedAttempt(ctx context.Context, session *models.VerificationSession) (expired bool, err error) {
+	const q = `UPDATE verification_sessions SET failed_attempts = failed_attempts + 1, challenge_data = ''
+WHERE token = $1 RETURNING failed_attempts`
+	err = common.PQ.QueryRowContext(ctx, q, session.Token).Scan(&session.FailedAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	session.ChallengeData = ""
+	if session.FailedAttempts < MaxFailedAttempts {
+		return false, nil
+	}
+
+	session.ExpiredAt = null.TimeFrom(time.Now())
+	_, err = session.UpdateG(ctx, boil.Whitelist("expired_at"))
+	return true, err
+}
@@ -39,6 +39,7 @@ type VerificationEventData struct {
 func (p *Plugin) BotInit() {
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleMemberJoin, eventsystem.EventGuildMemberAdd)
 	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleBanAdd, eventsystem.EventGuildBanAdd)
+	eventsystem.AddHandlerAsyncLastLegacy(p, p.handleBanRemove, eventsystem.EventGuildBanRemove)
 	scheduledevents2.RegisterHandler("verification_user_verified", int64(0), ScheduledEventMW(p.handleUserVerifiedScheduledEvent))
 	scheduledevents2.RegisterHandler("verification_user_warn", VerificationEventData{}, ScheduledEventMW(p.handleWarnUserVerification))
 	scheduledevents2.RegisterHandler("verification_user_kick", VerificationEventData{}, ScheduledEventMW(p.handleKickUser))
@@ -203,11 +204,6 @@ func ScheduledEventMW(innerHandler func(ms *dstate.MemberState, guildID int64, c
 }
 
 func (p *Plugin) handleUserVerifiedScheduledEvent(ms *dstate.MemberState, guildID int64, conf *models.VerificationConfig, rawData interface{}) (retry bool, err error) {
-	err = common.BotSession.GuildMemberRoleAdd(guildID, ms.User.ID, conf.VerifiedRole)
-	if err != nil {
-		return scheduledevents2.CheckDiscordErrRetry(err), err
-	}
-
 	model, err := models.FindVerifiedUserG(context.Background(), guildID, ms.User.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -221,26 +217,40 @@ func (p *Plugin) handleUserVerifiedScheduledEvent(ms *dstate.MemberState, guildI
 		return true, err
 	}
 
-	if !confVerificationTrackIPs.GetBool() || model.IP == "" {
-		p.logAction(guildID, conf.LogChannel, &ms.User, "User successfully verified", 0x49ed47)
-		return false, nil
+	// Check for IP conflicts
+	var conflicts []*discordgo.User
+	if confVerificationTrackIPs.GetBool() && model.IP != "" {
+		conflicts, err = p.findIPConflicts(guildID, ms.User.ID, model.IP)
+		if err != nil {
+			return scheduledevents2.CheckDiscordErrRetry(err), err
+		}
 	}
 
-	// Check for IP conflicts
-	conflicts, err := p.findIPConflicts(guildID, ms.User.ID, model.IP)
-	if err != nil {
-		return scheduledevents2.CheckDiscordErrRetry(err), err
+	// check if the user shares a IP with a banned user
+	var ban *discordgo.GuildBan
+	if len(conflicts) > 0 {
+		ban, err = p.CheckBanned(guildID, conflicts)
+		if err != nil {
+			return scheduledevents2.CheckDiscordErrRetry(err), err
+		}
 	}
 
-	if len(conflicts) < 1 {
-		p.logAction(guildID, conf.LogChannel, &ms.User, "User successfully verified", 0x49ed47)
-		return false, nil
+	factors, err := p.gatherAltRiskFactors(guildID, ms, conflicts, ban != nil)
+	if err != nil {
+		return true, err
 	}
 
-	// check if the user shares a IP with a banned user
-	ban, err := p.CheckBanned(guildID, conflicts)
+	score, reasons := factors.Score()
+	model.RiskScore = score
+	model.RiskReasons = strings.Join(reasons, "\n")
+	_, err = model.UpdateG(context.Background(), boil.Whitelist("risk_score", "risk_reasons"))
 	if err != nil {
-		return scheduledevents2.CheckDiscordErrRetry(err), err
+		return true, err
+	}
+
+	riskSummary := fmt.Sprintf("\n\n**Risk score: %d/100**", score)
+	if len(reasons) > 0 {
+		riskSummary += "\n" + strings.Join(reasons, "\n")
 	}
 
 	if ban != nil {
@@ -253,17 +263,33 @@ func (p *Plugin) handleUserVerifiedScheduledEvent(ms *dstate.MemberState, guildI
 			banReason = string(r) + "..."
 		}
 
+		markRecentlyBannedByVerification(guildID, ms.User.ID)
 		err := moderation.BanUser(nil, guildID, nil, nil, common.BotUser, banReason, &ms.User)
 		if err != nil {
 			return scheduledevents2.CheckDiscordErrRetry(err), err
 		}
 
-		p.logAction(guildID, conf.LogChannel, &ms.User, fmt.Sprintf("User banned for sharing IP with banned user %s#%s (%d)\nReason: %s",
-			ban.User.Username, ban.User.Discriminator, ban.User.ID, ban.Reason), 0xef4640)
+		p.logAction(guildID, conf.LogChannel, &ms.User, fmt.Sprintf("User banned for sharing IP with banned user %s#%s (%d)\nReason: %s%s",
+			ban.User.Username, ban.User.Discriminator, ban.User.ID, ban.Reason, riskSummary), 0xef4640)
 
 		return false, nil
 	}
 
+	if conf.AltActionThreshold > 0 && score >= conf.AltActionThreshold && (conf.AltAction == AltActionKick || conf.AltAction == AltActionBan) {
+		return p.takeAltAction(ms, guildID, conf, score, riskSummary)
+	}
+
+	// only give the role once we know the user isn't getting kicked or banned as an alt
+	err = common.BotSession.GuildMemberRoleAdd(guildID, ms.User.ID, conf.VerifiedRole)
+	if err != nil {
+		return scheduledevents2.CheckDiscordErrRetry(err), err
+	}
+
+	if len(conflicts) < 1 {
+		p.logAction(guildID, conf.LogChannel, &ms.User, "User successfully verified"+riskSummary, riskColor(score))
+		return false, nil
+	}
+
 	// Does not share the IP with a banned user, but warn about alt account
 	var builder strings.Builder
 	builder.WriteString("User verified but verified with the same IP as the following users: \n")
@@ -276,10 +302,96 @@ func (p *Plugin) handleUserVerifiedScheduledEvent(ms *dstate.MemberState, guildI
 		}
 	}
 
+	builder.WriteString(riskSummary)
+
 	p.logAction(guildID, conf.LogChannel, &ms.User, builder.String(), 0xff8228)
 	return false, nil
 }
 
+func (p *Plugin) takeAltAction(ms *dstate.MemberState, guildID int64, conf *models.VerificationConfig, score int, riskSummary string) (retry bool, err error) {
+	reason := fmt.Sprintf("Verification: alt account risk score %d is above the threshold of %d", score, conf.AltActionThreshold)
+
+	if conf.AltAction == AltActionBan {
+		markRecentlyBannedByVerification(guildID, ms.User.ID)
+		err = moderation.BanUser(nil, guildID, nil, nil, common.BotUser, reason, &ms.User)
+	} else {
+		err = common.BotSession.GuildMemberDelete(guildID, ms.User.ID)
+	}
+
+	if err != nil {
+		return scheduledevents2.CheckDiscordErrRetry(err), err
+	}
+
+	action := "kicked"
+	if conf.AltAction == AltActionBan {
+		action = "banned"
+	}
+
+	p.logAction(guildID, conf.LogChannel, &ms.User, fmt.Sprintf("User %s for being a likely alt account%s", action, riskSummary), 0xef4640)
+	return false, nil
+}
+
+// the log message color goes from green to orange to red as the risk score increases
+func riskColor(score int) int {
+	switch {
+	case score >= 60:
+		return 0xef4640
+	case score >= 30:
+		return 0xff8228
+	default:
+		return 0x49ed47
+	}
+}
+
+// the most recent bans we compare new users against
+const maxBannedUsersCompared = 1000
+
+func (p *Plugin) gatherAltRiskFactors(guildID int64, ms *dstate.MemberState, ipConflicts []*discordgo.User, sharesIPWithBanned bool) (*AltRiskFactors, error) {
+	factors := &AltRiskFactors{
+		AccountAge:         time.Since(bot.SnowflakeToTime(ms.User.ID)),
+		SharedIPUsers:      len(ipConflicts),
+		SharedIPWithBanned: sharesIPWithBanned,
+	}
+
+	banned, err := models.VerificationBannedUsers(
+		models.VerificationBannedUserWhere.GuildID.EQ(guildID),
+		models.VerificationBannedUserWhere.UserID.NEQ(ms.User.ID),
+		qm.OrderBy("banned_at desc"),
+		qm.Limit(maxBannedUsersCompared)).AllG(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	var joinedAt time.Time
+	if ms.Member != nil && ms.Member.JoinedAt != "" {
+		joinedAt, _ = ms.Member.JoinedAt.Parse()
+	}
+
+	for _, v := range banned {
+		sameAvatar := ms.User.Avatar != "" && v.Avatar == ms.User.Avatar
+		if sameAvatar && factors.SameAvatarAsBanned == "" {
+			factors.SameAvatarAsBanned = v.Username
+		}
+
+		similarity := NameSimilarity(ms.User.Username, v.Username)
+		if similarity > factors.NameSimilarity {
+			factors.NameSimilarity = similarity
+			factors.SimilarBannedName = v.Username
+		}
+
+		// only the bans of users this one looks related to count, not every ban on the server
+		related := sameAvatar || similarity >= altNameSimilarityThreshold
+		if related && !joinedAt.IsZero() && factors.JoinedAfterBan == 0 && joinedAt.After(v.BannedAt) {
+			// the bans are sorted newest first, so this is the closest related ban before the join
+			if since := joinedAt.Sub(v.BannedAt); since < altJoinAfterBanWindow {
+				factors.JoinedAfterBan = since
+			}
+		}
+	}
+
+	return factors, nil
+}
+
 func (p *Plugin) clearScheduledEvents(ctx context.Context, guildID, userID int64) error {
 	_, err := seventsmodels.ScheduledEvents(
 		qm.Where("(event_name='verification_user_warn' OR event_name='verification_user_kick')"),
@@ -523,6 +635,8 @@ func markRecentlyBannedByVerification(guildID int64, userID int64) {
 func (p *Plugin) handleBanAdd(evt *eventsystem.EventData) {
 	ban := evt.GuildBanAdd()
 
+	p.recordBannedUser(ban)
+
 	if !confVerificationTrackIPs.GetBool() {
 		return
 	}
@@ -582,3 +696,42 @@ func (p *Plugin) banAlts(ban *discordgo.GuildBanAdd, alts []*discordgo.User) {
 		logger.WithError(err).Error("failed retrieving guild ban")
 	}
 }
+
+// recordBannedUser stores the banned user so that new users can be compared against it in the alt detection
+func (p *Plugin) recordBannedUser(ban *discordgo.GuildBanAdd) {
+	enabled, err := models.VerificationConfigs(
+		models.VerificationConfigWhere.GuildID.EQ(ban.GuildID),
+		models.VerificationConfigWhere.Enabled.EQ(true)).ExistsG(context.Background())
+	if err != nil {
+		logger.WithError(err).WithField("guild", ban.GuildID).Error("failed checking verification config in banadd")
+		return
+	}
+
+	if !enabled {
+		return
+	}
+
+	model := &models.VerificationBannedUser{
+		GuildID:  ban.GuildID,
+		UserID:   ban.User.ID,
+		BannedAt: time.Now(),
+		Username: ban.User.Username,
+		Avatar:   ban.User.Avatar,
+	}
+
+	err = model.UpsertG(context.Background(), true, []string{"guild_id", "user_id"}, boil.Infer(), boil.Infer())
+	if err != nil {
+		logger.WithError(err).WithField("guild", ban.GuildID).Error("failed recording banned user")
+	}
+}
+
+func (p *Plugin) handleBanRemove(evt *eventsystem.EventData) {
+	ban := evt.GuildBanRemove()
+
+	_, err := models.VerificationBannedUsers(
+		models.VerificationBannedUserWhere.GuildID.EQ(ban.GuildID),
+		models.VerificationBannedUserWhere.UserID.EQ(ban.User.ID)).DeleteAll(context.Background(), common.PQ)
+	if err != nil {
+		logger.WithError(err).WithField("guild", ban.GuildID).Error("failed removing unbanned user")
+	}
+}
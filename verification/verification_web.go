@@ -9,17 +9,13 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
-	"time"
 
-	"github.com/jonas747/yagpdb/analytics"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/cplogs"
-	"github.com/jonas747/yagpdb/common/scheduledevents2"
 	"github.com/jonas747/yagpdb/verification/models"
 	"github.com/jonas747/yagpdb/web"
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/russross/blackfriday"
-	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
 	"goji.io/pat"
 )
@@ -33,6 +29,10 @@ type FormData struct {
 	WarnMessage         string `valid:"template,10000"`
 	DMMessage           string `valid:"template,10000"`
 	LogChannel          int64  `valid:"channel,true"`
+	ChallengeType       string
+	QuizQuestions       string `valid:",10000"`
+	AltAction           string
+	AltActionThreshold  int `valid:"0,100"`
 }
 
 var panelLogKey = cplogs.RegisterActionFormat(&cplogs.ActionFormat{Key: "verification_updated_settings", FormatString: "Updated verification settings"})
@@ -77,8 +77,13 @@ func (p *Plugin) handleGetSettings(w http.ResponseWriter, r *http.Request) (web.
 		settings.DMMessage = DefaultDMMessage
 	}
 
+	if settings != nil && settings.ChallengeType == "" {
+		settings.ChallengeType = string(ChallengeTypeReCAPTCHA)
+	}
+
 	templateData["DefaultPageContent"] = DefaultPageContent
 	templateData["PluginSettings"] = settings
+	templateData["ReCAPTCHAAvailable"] = reCAPTCHAAvailable()
 
 	return templateData, err
 }
@@ -89,6 +94,18 @@ func (p *Plugin) handlePostSettings(w http.ResponseWriter, r *http.Request) (web
 
 	formConfig := ctx.Value(common.ContextKeyParsedForm).(*FormData)
 
+	if _, ok := challenges[ChallengeType(formConfig.ChallengeType)]; !ok {
+		return templateData, web.NewPublicError("Unknown challenge type")
+	}
+
+	if formConfig.AltAction != "" && formConfig.AltAction != AltActionKick && formConfig.AltAction != AltActionBan {
+		return templateData, web.NewPublicError("Unknown alt account action")
+	}
+
+	if ChallengeType(formConfig.ChallengeType) == ChallengeTypeQuiz && len(ParseQuizQuestions(formConfig.QuizQuestions)) < 1 {
+		return templateData, web.NewPublicError("The rules quiz challenge needs atleast 1 question")
+	}
+
 	model := &models.VerificationConfig{
 		GuildID:             g.ID,
 		Enabled:             formConfig.Enabled,
@@ -99,10 +116,14 @@ func (p *Plugin) handlePostSettings(w http.ResponseWriter, r *http.Request) (web
 		WarnMessage:         formConfig.WarnMessage,
 		LogChannel:          formConfig.LogChannel,
 		DMMessage:           formConfig.DMMessage,
+		ChallengeType:       formConfig.ChallengeType,
+		QuizQuestions:       formConfig.QuizQuestions,
+		AltAction:           formConfig.AltAction,
+		AltActionThreshold:  formConfig.AltActionThreshold,
 	}
 
-	columns := boil.Whitelist("enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message")
-	columnsCreate := boil.Whitelist("guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message")
+	columns := boil.Whitelist("enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message", "challenge_type", "quiz_questions", "alt_action", "alt_action_threshold")
+	columnsCreate := boil.Whitelist("guild_id", "enabled", "verified_role", "page_content", "kick_unverified_after", "warn_unverified_after", "warn_message", "log_channel", "dm_message", "challenge_type", "quiz_questions", "alt_action", "alt_action_threshold")
 	err := model.UpsertG(ctx, true, []string{"guild_id"}, columns, columnsCreate)
 	if err == nil {
 		go cplogs.RetryAddEntry(web.NewLogEntryFromContext(r.Context(), panelLogKey))
@@ -132,11 +153,11 @@ func (p *Plugin) handleGetVerifyPage(w http.ResponseWriter, r *http.Request) (we
 		return templateData, nil
 	}
 
-	if _, ok := templateData["REValid"]; !ok {
+	if valid, _ := templateData["REValid"].(bool); !valid {
 		// check if there's a valid session if we didn't just finish verifying
 		userID, _ := strconv.ParseInt(pat.Param(r, "user_id"), 10, 64)
 		token := pat.Param(r, "token")
-		_, err = models.VerificationSessions(
+		verSession, err := models.VerificationSessions(
 			models.VerificationSessionWhere.UserID.EQ(userID),
 			models.VerificationSessionWhere.Token.EQ(token),
 			models.VerificationSessionWhere.ExpiredAt.IsNull(),
@@ -150,10 +171,15 @@ func (p *Plugin) handleGetVerifyPage(w http.ResponseWriter, r *http.Request) (we
 
 			return templateData, err
 		}
-	}
 
-	templateData["ExtraHead"] = template.HTML(`<script src="https://www.google.com/recaptcha/api.js" async defer></script>`)
-	templateData["GoogleReCaptchaSiteKey"] = confGoogleReCAPTCHASiteKey.GetString()
+		challengeType, challenge := ChallengeForConfig(settings)
+		err = challenge.Prepare(ctx, templateData, settings, verSession)
+		if err != nil {
+			return templateData, err
+		}
+
+		templateData["ChallengeType"] = string(challengeType)
+	}
 
 	msg := settings.PageContent
 	if msg == "" {
@@ -188,8 +214,6 @@ func (p *Plugin) handlePostVerifyPage(w http.ResponseWriter, r *http.Request) (w
 		return templateData, nil
 	}
 
-	valid, err := p.checkCAPTCHAResponse(r.FormValue("g-recaptcha-response"))
-
 	token := pat.Param(r, "token")
 	userID, _ := strconv.ParseInt(pat.Param(r, "user_id"), 10, 64)
 
@@ -208,33 +232,34 @@ func (p *Plugin) handlePostVerifyPage(w http.ResponseWriter, r *http.Request) (w
 		return templateData, err
 	}
 
+	_, challenge := ChallengeForConfig(settings)
+	valid, err := challenge.Check(r, settings, verSession)
+	if err != nil {
+		return templateData, err
+	}
+
 	if valid {
 		ip := ""
 		if confVerificationTrackIPs.GetBool() {
 			ip = web.GetRequestIP(r)
 		}
 
-		model := &models.VerifiedUser{
-			UserID:     userID,
-			GuildID:    g.ID,
-			VerifiedAt: time.Now(),
-			IP:         ip,
-		}
-
-		err := model.UpsertG(ctx, true, []string{"guild_id", "user_id"}, boil.Infer(), boil.Infer())
+		err := p.completeVerification(ctx, verSession, ip)
 		if err != nil {
 			web.CtxLogger(r.Context()).WithError(err).Error("failed verifying user")
 			return templateData, err
 		}
-
-		scheduledevents2.ScheduleEvent("verification_user_verified", g.ID, time.Now(), userID)
-		verSession.SolvedAt = null.TimeFrom(time.Now())
-		verSession.UpdateG(ctx, boil.Infer())
-
-		go analytics.RecordActiveUnit(g.ID, p, "completed")
-
 	} else {
-		templateData.AddAlerts(web.ErrorAlert("Invalid reCAPTCHA submission."))
+		expired, err := recordFailedAttempt(ctx, verSession)
+		if err != nil {
+			return templateData, err
+		}
+
+		if expired {
+			templateData.AddAlerts(web.ErrorAlert("Too many failed attempts, try rejoining the server or contact an admin to get a new verification link."))
+		} else {
+			templateData.AddAlerts(web.ErrorAlert(fmt.Sprintf("Invalid submission, please try again. %d attempts left.", MaxFailedAttempts-verSession.FailedAttempts)))
+		}
 	}
 
 	templateData["REValid"] = valid
@@ -254,7 +279,7 @@ type CheckCAPTCHARequest struct {
 	Response string `json:"response"`
 }
 
-func (p *Plugin) checkCAPTCHAResponse(response string) (valid bool, err error) {
+func checkCAPTCHAResponse(response string) (valid bool, err error) {
 
 	v := url.Values{
 		"response": {response},
@@ -288,7 +313,7 @@ func (p *Plugin) LoadServerHomeWidget(w http.ResponseWriter, r *http.Request) (w
 	ag, templateData := web.GetBaseCPContextData(r.Context())
 	ctx := r.Context()
 
-	templateData["WidgetTitle"] = "Google reCAPTCHA Verification"
+	templateData["WidgetTitle"] = "Verification"
 	templateData["SettingsPath"] = "/verification"
 
 	settings, err := models.FindVerificationConfigG(ctx, ag.ID)
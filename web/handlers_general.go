@@ -392,7 +392,7 @@ func pollCommandsRan() {
 
 		within := time.Now().Add(-24 * time.Hour)
 
-		err := common.GORM.Table(common.LoggedExecutedCommand{}.TableName()).Select("COUNT(*)").Where("created_at > ?", within).Scan(&result).Error
+		err := common.GORM.Table(common.LoggedExecutedCommand{}.TableName()).Select("COUNT(*)").Where("created_at > ? AND status != ?", within, common.ExecutedCommandStatusDenied).Scan(&result).Error
 		if err != nil {
 			logger.WithError(err).Error("failed counting commands ran today")
 		} else {
@@ -61,6 +61,7 @@ func (p *Plugin) runWebsubChecker() {
 	p.syncWebSubs()
 
 	websubTicker := time.NewTicker(WebSubCheckInterval)
+	liveTicker := time.NewTicker(LiveCheckInterval)
 	for {
 		select {
 		case wg := <-p.Stop:
@@ -68,6 +69,8 @@ func (p *Plugin) runWebsubChecker() {
 			return
 		case <-websubTicker.C:
 			p.checkExpiringWebsubs()
+		case <-liveTicker.C:
+			p.checkStreams()
 		}
 	}
 }
@@ -152,24 +155,34 @@ func (p *Plugin) removeAllSubsForChannel(channel string) {
 	go p.MaybeRemoveChannelWatch(channel)
 }
 
-func (p *Plugin) sendNewVidMessage(guild, discordChannel string, channelTitle string, videoID string, mentionEveryone bool) {
-	content := fmt.Sprintf("**%s** uploaded a new youtube video!\n%s", channelTitle, "https://www.youtube.com/watch?v="+videoID)
-	if mentionEveryone {
+func (p *Plugin) sendNewVidMessage(sub *ChannelSubscription, video *youtube.Video, kind VideoKind) {
+	videoURL := "https://www.youtube.com/watch?v=" + video.Id
+
+	var content string
+	switch kind {
+	case VideoKindShort:
+		content = fmt.Sprintf("**%s** uploaded a new youtube short!\n%s", video.Snippet.ChannelTitle, videoURL)
+	case VideoKindLivestream:
+		content = fmt.Sprintf("**%s** is now live on youtube!\n%s", video.Snippet.ChannelTitle, videoURL)
+	case VideoKindPremiere:
+		content = fmt.Sprintf("**%s** is premiering a new youtube video <t:%d:R>!\n%s", video.Snippet.ChannelTitle, videoStartTime(video).Unix(), videoURL)
+	default:
+		content = fmt.Sprintf("**%s** uploaded a new youtube video!\n%s", video.Snippet.ChannelTitle, videoURL)
+	}
+
+	if sub.MentionEveryone {
 		content += " @everyone"
 	}
 
-	parsedChannel, _ := strconv.ParseInt(discordChannel, 10, 64)
-	parsedGuild, _ := strconv.ParseInt(guild, 10, 64)
+	parsedChannel, _ := strconv.ParseInt(sub.ChannelID, 10, 64)
+	parsedGuild, _ := strconv.ParseInt(sub.GuildID, 10, 64)
 
 	parseMentions := []discordgo.AllowedMentionType{}
-	if mentionEveryone {
+	if sub.MentionEveryone {
 		parseMentions = []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeEveryone}
 	}
 
-	go analytics.RecordActiveUnit(parsedGuild, p, "posted_youtube_message")
-	feeds.MetricPostedMessages.With(prometheus.Labels{"source": "youtube"}).Inc()
-
-	mqueue.QueueMessage(&mqueue.QueuedElement{
+	elem := &mqueue.QueuedElement{
 		Guild:      parsedGuild,
 		Channel:    parsedChannel,
 		Source:     "youtube",
@@ -179,7 +192,57 @@ func (p *Plugin) sendNewVidMessage(guild, discordChannel string, channelTitle st
 		AllowedMentions: discordgo.AllowedMentions{
 			Parse: parseMentions,
 		},
-	})
+	}
+
+	if kind == VideoKindLivestream && sub.EditEndedStreams {
+		elem.MessageIDKey = KeyLiveMessage(video.Id, sub.ID)
+	}
+
+	if tmpl := sub.MessageTemplateForKind(kind); tmpl != "" {
+		custom, err := feeds.ExecuteMessageTemplate(parsedGuild, parsedChannel, "youtube_feed", tmpl, TemplateData(video, kind))
+		if err == feeds.ErrEmptyTemplateOutput {
+			return
+		}
+
+		if err != nil {
+			// post the standard message instead
+			logger.WithError(err).WithField("guild", sub.GuildID).Warn("failed executing youtube feed message template")
+		} else {
+			elem.MessageStr = custom.Content
+			elem.MessageEmbed = custom.Embed
+			elem.AllowedMentions = custom.AllowedMentions
+		}
+	}
+
+	go analytics.RecordActiveUnit(parsedGuild, p, "posted_youtube_message")
+	feeds.MetricPostedMessages.With(prometheus.Labels{"source": "youtube"}).Inc()
+
+	mqueue.QueueMessage(elem)
+}
+
+// TemplateData returns the data available in custom feed message templates
+func TemplateData(video *youtube.Video, kind VideoKind) map[string]interface{} {
+	thumbnail := ""
+	if t := video.Snippet.Thumbnails; t != nil {
+		for _, v := range []*youtube.Thumbnail{t.Maxres, t.High, t.Medium, t.Default} {
+			if v != nil && v.Url != "" {
+				thumbnail = v.Url
+				break
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"Title":       video.Snippet.Title,
+		"Author":      video.Snippet.ChannelTitle,
+		"URL":         "https://www.youtube.com/watch?v=" + video.Id,
+		"Thumbnail":   thumbnail,
+		"VideoID":     video.Id,
+		"ChannelID":   video.Snippet.ChannelId,
+		"Description": common.CutStringShort(video.Snippet.Description, 1000),
+		"Kind":        string(kind),
+		"StartTime":   videoStartTime(video),
+	}
 }
 
 var (
@@ -195,12 +258,10 @@ var (
 	ErrNoChannel = errors.New("No channel with that id found")
 )
 
-func (p *Plugin) AddFeed(guildID, discordChannelID int64, youtubeChannelID, youtubeUsername string, mentionEveryone bool) (*ChannelSubscription, error) {
-	sub := &ChannelSubscription{
-		GuildID:         discordgo.StrID(guildID),
-		ChannelID:       discordgo.StrID(discordChannelID),
-		MentionEveryone: mentionEveryone,
-	}
+// AddFeed creates the subscription, sub holds the per feed settings such as the message templates
+func (p *Plugin) AddFeed(guildID, discordChannelID int64, youtubeChannelID, youtubeUsername string, sub *ChannelSubscription) (*ChannelSubscription, error) {
+	sub.GuildID = discordgo.StrID(guildID)
+	sub.ChannelID = discordgo.StrID(discordChannelID)
 
 	call := p.YTService.Channels.List([]string{"snippet"})
 	if youtubeChannelID != "" {
@@ -315,58 +376,92 @@ func (p *Plugin) CheckVideo(videoID string, channelID string) error {
 		return err
 	}
 
-	lastVid, lastVidTime, err := p.getLastVidTimes(channelID)
-	if err != nil {
+	resp, err := p.YTService.Videos.List(videoParts).Id(videoID).Do()
+	if err != nil || len(resp.Items) < 1 {
 		return err
 	}
 
-	if lastVid == videoID {
-		// the video was already posted and was probably just edited
-		return nil
-	}
+	return p.handleVideo(subs, resp.Items[0], channelID)
+}
 
-	resp, err := p.YTService.Videos.List([]string{"snippet"}).Id(videoID).Do()
-	if err != nil || len(resp.Items) < 1 {
-		return err
-	}
+func (p *Plugin) handleVideo(subs []*ChannelSubscription, video *youtube.Video, channelID string) error {
+	kind := GetVideoKind(video)
+
+	switch kind {
+	case VideoKindUpcomingStream:
+		// posted once it goes live
+		return p.watchUpcomingStream(video)
+	case VideoKindEndedStream:
+		return p.handleEndedStream(subs, video)
+	case VideoKindLivestream, VideoKindPremiere:
+		if time.Since(videoStartTime(video)) > time.Hour {
+			// started a while ago, most likely the stream or premiere was just edited
+			return nil
+		}
+	default:
+		lastVid, lastVidTime, err := p.getLastVidTimes(channelID)
+		if err != nil {
+			return err
+		}
 
-	item := resp.Items[0]
+		if lastVid == video.Id {
+			// the video was already posted and was probably just edited
+			return nil
+		}
 
-	if item.Snippet.LiveBroadcastContent != "none" {
-		// ignore livestreams for now, might enable them at some point
-		return nil
-	}
+		parsedPublishedAt, err := time.Parse(time.RFC3339, video.Snippet.PublishedAt)
+		if err != nil {
+			return errors.New("Failed parsing youtube timestamp: " + err.Error() + ": " + video.Snippet.PublishedAt)
+		}
 
-	parsedPublishedAt, err := time.Parse(time.RFC3339, item.Snippet.PublishedAt)
-	if err != nil {
-		return errors.New("Failed parsing youtube timestamp: " + err.Error() + ": " + item.Snippet.PublishedAt)
-	}
+		if time.Since(parsedPublishedAt) > time.Hour {
+			// just a safeguard against empty lastVidTime's
+			return nil
+		}
 
-	if time.Since(parsedPublishedAt) > time.Hour {
-		// just a safeguard against empty lastVidTime's
-		return nil
-	}
+		if lastVidTime.After(parsedPublishedAt) {
+			// wasn't a new vid
+			return nil
+		}
 
-	if lastVidTime.After(parsedPublishedAt) {
-		// wasn't a new vid
-		return nil
+		err = common.MultipleCmds(
+			radix.FlatCmd(nil, "SET", KeyLastVidTime(channelID), parsedPublishedAt.Unix()),
+			radix.FlatCmd(nil, "SET", KeyLastVidID(channelID), video.Id),
+		)
+		if err != nil {
+			return err
+		}
 	}
 
 	// This is a new video, post it
-	return p.postVideo(subs, parsedPublishedAt, item, channelID)
+	return p.postVideo(subs, video, kind)
 }
 
-func (p *Plugin) postVideo(subs []*ChannelSubscription, publishedAt time.Time, video *youtube.Video, channelID string) error {
+func (p *Plugin) postVideo(subs []*ChannelSubscription, video *youtube.Video, kind VideoKind) error {
+	// premieres and streams can be updated a lot, make sure we only post them once
+	var added int
 	err := common.MultipleCmds(
-		radix.FlatCmd(nil, "SET", KeyLastVidTime(channelID), publishedAt.Unix()),
-		radix.FlatCmd(nil, "SET", KeyLastVidID(channelID), video.Id),
+		radix.Cmd(&added, "SADD", KeyPostedVideo(video.Id), string(kind)),
+		radix.FlatCmd(nil, "EXPIRE", KeyPostedVideo(video.Id), 60*60*24*7),
 	)
-	if err != nil {
+	if err != nil || added == 0 {
 		return err
 	}
 
+	trackEnd := false
 	for _, sub := range subs {
-		p.sendNewVidMessage(sub.GuildID, sub.ChannelID, video.Snippet.ChannelTitle, video.Id, sub.MentionEveryone)
+		if !sub.WantsKind(kind) {
+			continue
+		}
+
+		p.sendNewVidMessage(sub, video, kind)
+		if kind == VideoKindLivestream && sub.EditEndedStreams {
+			trackEnd = true
+		}
+	}
+
+	if trackEnd {
+		return common.RedisPool.Do(radix.FlatCmd(nil, "ZADD", RedisKeyLiveStreams, time.Now().Unix(), video.Id))
 	}
 
 	return nil
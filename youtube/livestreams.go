@@ -0,0 +1,167 @@
+package youtube
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jonas747/yagpdb/common"
+	"github.com/mediocregopher/radix/v3"
+	"google.golang.org/api/youtube/v3"
+)
+
+const (
+	// youtube doesn't reliably notify us when scheduled streams go live or streams end, so we poll them instead
+	LiveCheckInterval = time.Minute
+
+	// give up on streams that hasn't started this long after their scheduled start
+	maxStreamDelay = time.Hour * 12
+	// and stop tracking streams that has been live for longer than this
+	maxStreamDuration = time.Hour * 48
+
+	// max ids per videos.list call
+	maxVideosPerRequest = 50
+)
+
+func (p *Plugin) watchUpcomingStream(video *youtube.Video) error {
+	start := videoStartTime(video)
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	return common.RedisPool.Do(radix.FlatCmd(nil, "ZADD", RedisKeyUpcomingStreams, start.Unix(), video.Id))
+}
+
+// handleEndedStream edits the livestream announcements of the subscriptions that has that enabled
+func (p *Plugin) handleEndedStream(subs []*ChannelSubscription, video *youtube.Video) error {
+	err := common.RedisPool.Do(radix.Cmd(nil, "ZREM", RedisKeyLiveStreams, video.Id))
+	if err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("**%s** was live on youtube: **%s**\n<https://www.youtube.com/watch?v=%s>", video.Snippet.ChannelTitle, video.Snippet.Title, video.Id)
+
+	for _, sub := range subs {
+		if !sub.EditEndedStreams || !sub.PublishLivestreams {
+			continue
+		}
+
+		var messageID int64
+		err := common.RedisPool.Do(radix.Cmd(&messageID, "GET", KeyLiveMessage(video.Id, sub.ID)))
+		if err != nil || messageID == 0 {
+			continue
+		}
+
+		channelID, _ := strconv.ParseInt(sub.ChannelID, 10, 64)
+		_, err = common.BotSession.ChannelMessageEdit(channelID, messageID, content)
+		if err != nil {
+			logger.WithError(err).WithField("guild", sub.GuildID).Debug("failed editing ended livestream announcement")
+		}
+
+		common.RedisPool.Do(radix.Cmd(nil, "DEL", KeyLiveMessage(video.Id, sub.ID)))
+	}
+
+	return nil
+}
+
+// checkStreams checks if scheduled streams has gone live, and if tracked livestreams has ended
+func (p *Plugin) checkStreams() {
+	now := time.Now()
+
+	var upcoming []string
+	err := common.RedisPool.Do(radix.FlatCmd(&upcoming, "ZRANGEBYSCORE", RedisKeyUpcomingStreams, "-inf", now.Unix(), "LIMIT", 0, maxVideosPerRequest))
+	if err != nil {
+		logger.WithError(err).Error("failed retrieving upcoming streams")
+		return
+	}
+
+	var live []string
+	err = common.RedisPool.Do(radix.FlatCmd(&live, "ZRANGE", RedisKeyLiveStreams, 0, maxVideosPerRequest-1))
+	if err != nil {
+		logger.WithError(err).Error("failed retrieving live streams")
+		return
+	}
+
+	ids := make([]string, 0, len(upcoming)+len(live))
+	ids = append(ids, upcoming...)
+	ids = append(ids, live...)
+
+	// videos.list accepts at most maxVideosPerRequest ids per call
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > maxVideosPerRequest {
+			n = maxVideosPerRequest
+		}
+
+		p.checkStreamsChunk(ids[:n], now)
+		ids = ids[n:]
+	}
+}
+
+func (p *Plugin) checkStreamsChunk(ids []string, now time.Time) {
+	resp, err := p.YTService.Videos.List(videoParts).Id(ids...).Do()
+	if err != nil {
+		logger.WithError(err).Error("failed checking streams")
+		return
+	}
+
+	found := make(map[string]bool)
+	for _, video := range resp.Items {
+		found[video.Id] = true
+
+		err := p.checkStream(video, now)
+		if err != nil {
+			logger.WithError(err).WithField("video", video.Id).Error("failed checking stream")
+		}
+	}
+
+	// deleted or privated videos
+	for _, id := range ids {
+		if !found[id] {
+			common.RedisPool.Do(radix.Cmd(nil, "ZREM", RedisKeyUpcomingStreams, id))
+			common.RedisPool.Do(radix.Cmd(nil, "ZREM", RedisKeyLiveStreams, id))
+		}
+	}
+}
+
+func (p *Plugin) checkStream(video *youtube.Video, now time.Time) error {
+	kind := GetVideoKind(video)
+	if kind == VideoKindUpcomingStream {
+		if now.Sub(videoStartTime(video)) > maxStreamDelay {
+			return common.RedisPool.Do(radix.Cmd(nil, "ZREM", RedisKeyUpcomingStreams, video.Id))
+		}
+
+		// delayed or rescheduled
+		return p.watchUpcomingStream(video)
+	}
+
+	err := common.RedisPool.Do(radix.Cmd(nil, "ZREM", RedisKeyUpcomingStreams, video.Id))
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case VideoKindLivestream:
+		if now.Sub(videoStartTime(video)) > maxStreamDuration {
+			return common.RedisPool.Do(radix.Cmd(nil, "ZREM", RedisKeyLiveStreams, video.Id))
+		}
+
+		// move it to the back of the line
+		err = common.RedisPool.Do(radix.FlatCmd(nil, "ZADD", RedisKeyLiveStreams, "XX", now.Unix(), video.Id))
+		if err != nil {
+			return err
+		}
+	case VideoKindEndedStream:
+	default:
+		// not a livestream after all
+		return common.RedisPool.Do(radix.Cmd(nil, "ZREM", RedisKeyLiveStreams, video.Id))
+	}
+
+	subs, err := p.getRemoveSubs(video.Snippet.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	// posts the streams that just went live and edits the announcements of the ones that ended
+	return p.handleVideo(subs, video, video.Snippet.ChannelId)
+}
@@ -0,0 +1,172 @@
+package youtube
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+type VideoKind string
+
+const (
+	VideoKindUpload     VideoKind = "upload"
+	VideoKindShort      VideoKind = "short"
+	VideoKindLivestream VideoKind = "livestream"
+	VideoKindPremiere   VideoKind = "premiere"
+
+	// scheduled livestreams are watched and posted once they go live
+	VideoKindUpcomingStream VideoKind = "upcoming_stream"
+	// livestreams that has ended, they are not posted as new videos but the livestream announcements are edited
+	VideoKindEndedStream VideoKind = "ended_stream"
+)
+
+// youtube allows shorts up to 3 minutes long, only videos this short or shorter are checked with isShort
+const MaxShortDuration = time.Minute * 3
+
+// the api doesn't tell if a video is a short, but the /shorts/ url only works for shorts,
+// other videos are redirected to the normal watch page
+var shortsURL = "https://www.youtube.com/shorts/"
+
+var shortsHTTPClient = &http.Client{
+	Timeout: time.Second * 10,
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// isShort returns true if the video is a short, it's a variable so that it can be replaced in tests
+var isShort = func(videoID string) (bool, error) {
+	resp, err := shortsHTTPClient.Head(shortsURL + videoID)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// the video parts needed to figure out the kind of the video
+var videoParts = []string{"snippet", "contentDetails", "liveStreamingDetails"}
+
+// GetVideoKind returns the kind of video, the video needs the parts in videoParts
+func GetVideoKind(video *youtube.Video) VideoKind {
+	var duration time.Duration
+	if video.ContentDetails != nil {
+		duration, _ = ParseISODuration(video.ContentDetails.Duration)
+	}
+
+	// premieres are scheduled like livestreams, but they're prerecorded so the length of the video is already known,
+	// while the duration of livestreams is 0 until they've ended
+	premiere := duration > 0 && video.LiveStreamingDetails != nil && video.LiveStreamingDetails.ScheduledStartTime != ""
+
+	switch video.Snippet.LiveBroadcastContent {
+	case "live":
+		if premiere {
+			return VideoKindPremiere
+		}
+		return VideoKindLivestream
+	case "upcoming":
+		if premiere {
+			return VideoKindPremiere
+		}
+		return VideoKindUpcomingStream
+	}
+
+	if video.LiveStreamingDetails != nil && video.LiveStreamingDetails.ActualEndTime != "" {
+		return VideoKindEndedStream
+	}
+
+	if duration > 0 && duration <= MaxShortDuration {
+		short, err := isShort(video.Id)
+		if err != nil {
+			logger.WithError(err).WithField("video", video.Id).Error("failed checking if video is a short")
+		} else if short {
+			return VideoKindShort
+		}
+	}
+
+	return VideoKindUpload
+}
+
+// WantsKind returns true if the subscription should post videos of the kind
+func (c *ChannelSubscription) WantsKind(kind VideoKind) bool {
+	switch kind {
+	case VideoKindUpload:
+		return !c.IgnoreUploads
+	case VideoKindShort:
+		return !c.IgnoreShorts
+	case VideoKindLivestream:
+		return c.PublishLivestreams
+	case VideoKindPremiere:
+		return c.PublishPremieres
+	}
+
+	return false
+}
+
+// MessageTemplateForKind returns the custom message template for the kind, if any
+func (c *ChannelSubscription) MessageTemplateForKind(kind VideoKind) string {
+	t := ""
+	switch kind {
+	case VideoKindShort:
+		t = c.ShortMessageTemplate
+	case VideoKindLivestream:
+		t = c.LivestreamMessageTemplate
+	case VideoKindPremiere:
+		t = c.PremiereMessageTemplate
+	}
+
+	if t == "" {
+		t = c.MessageTemplate
+	}
+
+	return t
+}
+
+// videoStartTime returns the actual start time of a livestream or premiere, or the scheduled start if it hasn't started yet
+func videoStartTime(video *youtube.Video) time.Time {
+	details := video.LiveStreamingDetails
+	if details == nil {
+		return time.Time{}
+	}
+
+	ts := details.ActualStartTime
+	if ts == "" {
+		ts = details.ScheduledStartTime
+	}
+
+	parsed, _ := time.Parse(time.RFC3339, ts)
+	return parsed
+}
+
+var isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ParseISODuration parses the ISO 8601 durations used by the youtube api, such as PT1M30S
+func ParseISODuration(s string) (time.Duration, error) {
+	m := isoDurationRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration: %q", s)
+	}
+
+	units := []time.Duration{time.Hour * 24, time.Hour, time.Minute, time.Second}
+
+	var result time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+
+		n, err := strconv.ParseInt(m[i+1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		result += time.Duration(n) * unit
+	}
+
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package youtube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestParseISODuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"PT0S":       0,
+		"PT59S":      time.Second * 59,
+		"PT1M30S":    time.Minute + time.Second*30,
+		"PT2H":       time.Hour * 2,
+		"P1DT1H1M1S": time.Hour*25 + time.Minute + time.Second,
+	}
+
+	for input, expected := range cases {
+		parsed, err := ParseISODuration(input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", input, err)
+			continue
+		}
+
+		if parsed != expected {
+			t.Errorf("%s: expected %s, got %s", input, expected, parsed)
+		}
+	}
+
+	if _, err := ParseISODuration("1M30S"); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+}
+
+func TestGetVideoKind(t *testing.T) {
+	defer func(orig func(string) (bool, error)) { isShort = orig }(isShort)
+	isShort = func(videoID string) (bool, error) {
+		return videoID == "short", nil
+	}
+
+	video := func(id, broadcast, duration, scheduled, endTime string) *youtube.Video {
+		v := &youtube.Video{
+			Id:             id,
+			Snippet:        &youtube.VideoSnippet{LiveBroadcastContent: broadcast},
+			ContentDetails: &youtube.VideoContentDetails{Duration: duration},
+		}
+
+		if scheduled != "" || endTime != "" {
+			v.LiveStreamingDetails = &youtube.VideoLiveStreamingDetails{ScheduledStartTime: scheduled, ActualEndTime: endTime}
+		}
+
+		return v
+	}
+
+	const scheduled = "2021-01-01T00:00:00Z"
+
+	cases := []struct {
+		video    *youtube.Video
+		expected VideoKind
+	}{
+		{video("", "none", "PT10M", "", ""), VideoKindUpload},
+		{video("short", "none", "PT45S", "", ""), VideoKindShort},
+		{video("short", "none", "PT2M30S", "", ""), VideoKindShort},
+		{video("", "none", "PT45S", "", ""), VideoKindUpload},
+		{video("short", "none", "PT10M", "", ""), VideoKindUpload},
+		{video("", "live", "P0D", scheduled, ""), VideoKindLivestream},
+		{video("", "live", "P0D", "", ""), VideoKindLivestream},
+		{video("", "upcoming", "P0D", scheduled, ""), VideoKindUpcomingStream},
+		{video("", "upcoming", "PT5M", scheduled, ""), VideoKindPremiere},
+		{video("", "live", "PT5M", scheduled, ""), VideoKindPremiere},
+		{video("", "live", "PT5M", "", ""), VideoKindLivestream},
+		{video("", "none", "PT3H", scheduled, "2021-01-01T03:00:00Z"), VideoKindEndedStream},
+	}
+
+	for i, c := range cases {
+		if kind := GetVideoKind(c.video); kind != c.expected {
+			t.Errorf("case %d: expected %s, got %s", i, c.expected, kind)
+		}
+	}
+}
+
+func TestIsShort(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/shorts/short" {
+			return
+		}
+
+		http.Redirect(w, r, "/watch?v="+strings.TrimPrefix(r.URL.Path, "/shorts/"), http.StatusSeeOther)
+	}))
+	defer srv.Close()
+
+	defer func(orig string) { shortsURL = orig }(shortsURL)
+	shortsURL = srv.URL + "/shorts/"
+
+	for id, expected := range map[string]bool{"short": true, "regular": false} {
+		short, err := isShort(id)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", id, err)
+		}
+
+		if short != expected {
+			t.Errorf("%s: expected %t, got %t", id, expected, short)
+		}
+	}
+}
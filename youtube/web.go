@@ -12,12 +12,14 @@ import (
 	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/jonas747/discordgo"
 	"github.com/jonas747/yagpdb/common"
 	"github.com/jonas747/yagpdb/common/cplogs"
+	"github.com/jonas747/yagpdb/feeds"
 	"github.com/jonas747/yagpdb/web"
 	"github.com/mediocregopher/radix/v3"
 	"goji.io"
@@ -42,6 +44,46 @@ type Form struct {
 	DiscordChannel     int64 `valid:"channel,false"`
 	ID                 uint
 	MentionEveryone    bool
+	MessageTemplate    string
+
+	IgnoreUploads      bool
+	IgnoreShorts       bool
+	PublishLivestreams bool
+	PublishPremieres   bool
+	EditEndedStreams   bool
+
+	ShortMessageTemplate      string
+	LivestreamMessageTemplate string
+	PremiereMessageTemplate   string
+}
+
+// validateTemplates trims and validates the message templates of the form
+func (f *Form) validateTemplates() error {
+	templates := []*string{&f.MessageTemplate, &f.ShortMessageTemplate, &f.LivestreamMessageTemplate, &f.PremiereMessageTemplate}
+	for _, t := range templates {
+		*t = strings.TrimSpace(*t)
+		if err := feeds.ValidateMessageTemplate(*t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// apply copies the feed settings of the form over to sub
+func (f *Form) apply(sub *ChannelSubscription) {
+	sub.MentionEveryone = f.MentionEveryone
+	sub.MessageTemplate = f.MessageTemplate
+
+	sub.IgnoreUploads = f.IgnoreUploads
+	sub.IgnoreShorts = f.IgnoreShorts
+	sub.PublishLivestreams = f.PublishLivestreams
+	sub.PublishPremieres = f.PublishPremieres
+	sub.EditEndedStreams = f.EditEndedStreams
+
+	sub.ShortMessageTemplate = f.ShortMessageTemplate
+	sub.LivestreamMessageTemplate = f.LivestreamMessageTemplate
+	sub.PremiereMessageTemplate = f.PremiereMessageTemplate
 }
 
 func (p *Plugin) InitWeb() {
@@ -107,13 +149,20 @@ func (p *Plugin) HandleNew(w http.ResponseWriter, r *http.Request) (web.Template
 
 	data := ctx.Value(common.ContextKeyParsedForm).(*Form)
 
+	if err := data.validateTemplates(); err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid message template: " + err.Error())), nil
+	}
+
 	cID := trimYouTubeURLParts(data.YoutubeChannelID)
 	username := trimYouTubeURLParts(data.YoutubeChannelUser)
 	if cID == "" && username == "" {
 		return templateData.AddAlerts(web.ErrorAlert("Neither channelid or username specified.")), errors.New("ChannelID and username not specified")
 	}
 
-	sub, err := p.AddFeed(activeGuild.ID, data.DiscordChannel, cID, username, data.MentionEveryone)
+	sub := &ChannelSubscription{}
+	data.apply(sub)
+
+	sub, err := p.AddFeed(activeGuild.ID, data.DiscordChannel, cID, username, sub)
 	if err != nil {
 		if err == ErrNoChannel {
 			return templateData.AddAlerts(web.ErrorAlert("No channel by that id/username found")), errors.New("Channel not found")
@@ -177,7 +226,11 @@ func (p *Plugin) HandleEdit(w http.ResponseWriter, r *http.Request) (templateDat
 	sub := ctx.Value(ContextKeySub).(*ChannelSubscription)
 	data := ctx.Value(common.ContextKeyParsedForm).(*Form)
 
-	sub.MentionEveryone = data.MentionEveryone
+	if err := data.validateTemplates(); err != nil {
+		return templateData.AddAlerts(web.ErrorAlert("Invalid message template: " + err.Error())), nil
+	}
+
+	data.apply(sub)
 	sub.ChannelID = discordgo.StrID(data.DiscordChannel)
 
 	err = common.GORM.Save(sub).Error
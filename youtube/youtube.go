@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
 	"time"
 
@@ -21,6 +22,10 @@ const (
 
 	RedisKeyWebSubChannels = "youtube_registered_websub_channels"
 	GoogleWebsubHub        = "https://pubsubhubbub.appspot.com/subscribe"
+
+	// sorted sets of video ids, scored by their scheduled start and by when they went live
+	RedisKeyUpcomingStreams = "youtube_upcoming_streams"
+	RedisKeyLiveStreams     = "youtube_live_streams"
 )
 
 var (
@@ -31,6 +36,10 @@ var (
 
 func KeyLastVidTime(channel string) string { return "youtube_last_video_time:" + channel }
 func KeyLastVidID(channel string) string   { return "youtube_last_video_id:" + channel }
+func KeyPostedVideo(videoID string) string { return "youtube_posted_video:" + videoID }
+func KeyLiveMessage(videoID string, subID uint) string {
+	return "youtube_live_message:" + videoID + ":" + strconv.FormatUint(uint64(subID), 10)
+}
 
 type Plugin struct {
 	YTService *youtube.Service
@@ -67,6 +76,20 @@ type ChannelSubscription struct {
 	YoutubeChannelID   string
 	YoutubeChannelName string
 	MentionEveryone    bool
+	MessageTemplate    string `gorm:"type:text"`
+
+	// uploads and shorts are posted by default, livestreams and premieres are opt in
+	IgnoreUploads      bool
+	IgnoreShorts       bool
+	PublishLivestreams bool
+	PublishPremieres   bool
+	// edit the livestream announcement when the stream ends
+	EditEndedStreams bool
+
+	// custom templates for the other kinds of videos, MessageTemplate is used if not set
+	ShortMessageTemplate      string `gorm:"type:text"`
+	LivestreamMessageTemplate string `gorm:"type:text"`
+	PremiereMessageTemplate   string `gorm:"type:text"`
 }
 
 func (c *ChannelSubscription) TableName() string {